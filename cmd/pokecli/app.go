@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/cmdline"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/commands"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokecache"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/render"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/shortcuts"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/sprite"
+)
+
+// app is the state shared by the REPL and the one-shot command line mode.
+type app struct {
+	client    *pokeclient.Client
+	trainer   *poketrainer.Trainer
+	registry  *commands.Registry
+	shortcuts *shortcuts.Shortcuts
+	renderer  *render.Renderer
+	eventLog  *os.File
+}
+
+func newApp(logger *slog.Logger, themeName string) (*app, error) {
+	theme, ok := render.LookupTheme(themeName)
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q: want one of %s", themeName, strings.Join(render.ThemeNames(), ", "))
+	}
+
+	var (
+		cacheCleanupInterval = 30 * time.Minute
+		httpTimeout          = 10 * time.Second
+		cacheMaxBytes        = 64 * 1024 * 1024
+		cacheMaxEntries      = 2000
+	)
+
+	clientOptions := []pokeclient.Option{
+		pokeclient.WithLogger(logger),
+		pokeclient.WithCacheOptions(
+			pokecache.WithMaxBytes(cacheMaxBytes),
+			pokecache.WithMaxEntries(cacheMaxEntries),
+		),
+	}
+
+	// The sprites are still cached in memory if there is no cache directory.
+	if userCacheDir, err := os.UserCacheDir(); err == nil {
+		clientOptions = append(
+			clientOptions,
+			pokeclient.WithSpriteCacheDir(filepath.Join(userCacheDir, appName, spritesDirname)),
+		)
+	}
+
+	application := app{
+		client:    pokeclient.NewClient(cacheCleanupInterval, httpTimeout, clientOptions...),
+		trainer:   poketrainer.NewTrainer(),
+		registry:  commands.NewRegistry(),
+		shortcuts: shortcuts.New(),
+		renderer:  render.NewRenderer(theme, sprite.ColourModeFromEnv(os.Getenv)),
+		eventLog:  nil,
+	}
+
+	if dir, err := configDir(); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: %v; your progress will not be saved.\n", err)
+	} else {
+		trainer, eventLog, err := loadTrainer(filepath.Join(dir, eventLogFilename))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: %v; your progress will not be saved.\n", err)
+		} else {
+			application.trainer = trainer
+			application.eventLog = eventLog
+		}
+
+		shortcutSet, err := shortcuts.Load(filepath.Join(dir, shortcutsFilename))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: %v; your aliases and macros will not be saved.\n", err)
+		} else {
+			application.shortcuts = shortcutSet
+		}
+	}
+
+	if err := application.registerCommands(); err != nil {
+		application.close()
+
+		return nil, fmt.Errorf("unable to register the commands: %w", err)
+	}
+
+	return &application, nil
+}
+
+func (a *app) registerCommands() error {
+	client, trainer := a.client, a.trainer
+
+	if err := a.registry.Register(
+		commands.AbilityCommand(client),
+		commands.AchievementsCommand(trainer),
+		commands.AliasCommand(a.shortcuts, a.registry),
+		commands.BerryCommand(client),
+		commands.CacheCommand(client),
+		commands.CatchCommand(client, trainer),
+		commands.CompareCommand(client, trainer),
+		commands.CountCommand(),
+		commands.DaycareCommand(client, trainer),
+		commands.EggsCommand(trainer),
+		commands.ExitCommand(),
+		commands.ExploreCommand(client, trainer),
+		commands.GrepCommand(),
+		commands.HostCommand(trainer),
+		commands.InspectCommand(client, trainer, a.renderer),
+		commands.ItemCommand(client),
+		commands.JoinCommand(trainer),
+		commands.MacroCommand(a.shortcuts, a.registry),
+		commands.MapCommand(client, trainer),
+		commands.MapBCommand(client, trainer),
+		commands.PokedexCommand(trainer, a.renderer),
+		commands.RedoCommand(trainer),
+		commands.ReleaseCommand(trainer),
+		commands.StatsCommand(trainer),
+		commands.ThemeCommand(a.renderer),
+		commands.TradeCommand(trainer),
+		commands.UndoCommand(trainer),
+		commands.VisitCommand(client, trainer),
+		commands.WhereCommand(client, trainer),
+	); err != nil {
+		return err
+	}
+
+	return a.registry.Register(commands.HelpCommand(a.registry))
+}
+
+// run resolves the aliases and macros in the pipeline and runs the resulting
+// pipelines in order. It stops at the first pipeline that fails.
+func (a *app) run(pipeline cmdline.Pipeline) error {
+	pipelines, err := a.shortcuts.Expand(pipeline)
+	if err != nil {
+		return err
+	}
+
+	for _, pipeline := range slices.All(pipelines) {
+		if err := a.runPipeline(pipeline); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runPipeline runs the command in the pipeline. Pressing Ctrl-C while the
+// command is running cancels the command instead of exiting the application.
+func (a *app) runPipeline(pipeline cmdline.Pipeline) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := a.execute(ctx, os.Stdout, pipeline)
+	if ctx.Err() != nil {
+		return errCancelled
+	}
+
+	return err
+}
+
+// runCommand runs a single command for the terminal UI.
+func (a *app) runCommand(ctx context.Context, out io.Writer, args []string) error {
+	return a.execute(ctx, out, cmdline.Pipeline{args})
+}
+
+// execute runs the command in the pipeline and takes a step towards
+// hatching the trainer's eggs.
+func (a *app) execute(ctx context.Context, out io.Writer, pipeline cmdline.Pipeline) error {
+	err := a.registry.RunPipeline(ctx, out, pipeline)
+
+	if ctx.Err() != nil || errors.Is(err, commands.ErrUnknownCommand) || errors.Is(err, commands.ErrExit) {
+		return err
+	}
+
+	// Every action counts as a step towards hatching eggs.
+	if stepErr := commands.DaycareStep(ctx, out, a.client, a.trainer); stepErr != nil {
+		return errors.Join(err, stepErr)
+	}
+
+	return err
+}
+
+// printError prints the error in the theme's colour for errors.
+func (a *app) printError(out io.Writer, err error) {
+	fmt.Fprintln(out, a.renderer.Painter(out).Error(fmt.Sprintf("ERROR: %v.", err)))
+}
+
+func (a *app) close() {
+	_ = a.client.Close()
+
+	if a.eventLog != nil {
+		_ = a.eventLog.Close()
+	}
+}
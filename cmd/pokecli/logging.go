@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// newLogger creates the logger for the application's diagnostics. Only warnings
+// and errors are logged unless verbose or debug logging is enabled. The logs are
+// written to standard error unless a log file is specified. The returned function
+// closes the log file.
+func newLogger(verbose, debug bool, logFile string) (*slog.Logger, func(), error) {
+	level := slog.LevelWarn
+
+	switch {
+	case debug:
+		level = slog.LevelDebug
+	case verbose:
+		level = slog.LevelInfo
+	}
+
+	var (
+		destination io.Writer = os.Stderr
+		closeFunc             = func() {}
+	)
+
+	if logFile != "" {
+		file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to open the log file: %w", err)
+		}
+
+		destination = file
+		closeFunc = func() { _ = file.Close() }
+	}
+
+	logger := slog.New(slog.NewTextHandler(destination, &slog.HandlerOptions{Level: level}))
+
+	return logger, closeFunc, nil
+}
@@ -1,5 +1,87 @@
 package main
 
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/cmdline"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/commands"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/render"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/tui"
+)
+
+// tuiMode is the argument that starts the full-screen terminal UI.
+const tuiMode = "tui"
+
 func main() {
-	repl()
+	os.Exit(run())
+}
+
+func run() int {
+	var (
+		verbose bool
+		debug   bool
+		logFile string
+		theme   string
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [tui | command [arguments]]\n\n", appName)
+		fmt.Fprint(flag.CommandLine.Output(), "Starts the REPL, the full-screen terminal UI with tui, or runs a single command if one is specified.\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+
+	flag.BoolVar(&verbose, "verbose", false, "log the requests sent to the PokeAPI, their timings and the data served from the cache")
+	flag.BoolVar(&debug, "debug", false, "log everything from --verbose along with retries, conditional requests and prefetches")
+	flag.StringVar(&logFile, "log-file", "", "write the logs to this file instead of standard error")
+	flag.StringVar(
+		&theme,
+		"theme",
+		render.DefaultTheme,
+		"the colour theme of the output ("+strings.Join(render.ThemeNames(), ", ")+")",
+	)
+	flag.Parse()
+
+	logger, closeLog, err := newLogger(verbose, debug, logFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v.\n", err)
+
+		return 1
+	}
+	defer closeLog()
+
+	application, err := newApp(logger, theme)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v.\n", err)
+
+		return 1
+	}
+	defer application.close()
+
+	if flag.NArg() == 0 {
+		repl(application)
+
+		return 0
+	}
+
+	if flag.NArg() == 1 && flag.Arg(0) == tuiMode {
+		if err := tui.Run(application.client, application.trainer, application.runCommand); err != nil {
+			application.printError(os.Stderr, err)
+
+			return 1
+		}
+
+		return 0
+	}
+
+	if err := application.run(cmdline.Pipeline{flag.Args()}); err != nil && !errors.Is(err, commands.ErrExit) {
+		application.printError(os.Stderr, err)
+
+		return 1
+	}
+
+	return 0
 }
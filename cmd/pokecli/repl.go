@@ -2,130 +2,74 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
-	"strings"
-	"time"
+	"slices"
 
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/cmdline"
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/commands"
-	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
-	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
 )
 
-type command struct {
-	description string
-	callback    commands.CommandFunc
-}
-
-func repl() {
-	var (
-		cacheCleanupInterval = 30 * time.Minute
-		httpTimeout          = 10 * time.Second
-		client               = pokeclient.NewClient(cacheCleanupInterval, httpTimeout)
-		trainer              = poketrainer.NewTrainer()
-	)
-
-	commandMap := map[string]command{
-		"catch": {
-			description: "Catch a Pokemon and add it to your Pokedex",
-			callback:    commands.CatchFunc(client, trainer),
-		},
-		"exit": {
-			description: "Exit the Pokedex",
-			callback:    commands.ExitProgram,
-		},
-		"explore": {
-			description: "List all the Pokemon in a given area",
-			callback:    commands.ExploreFunc(client, trainer),
-		},
-		"help": {
-			description: "Display the help message",
-			callback:    nil,
-		},
-		"inspect": {
-			description: "Inspect a Pokemon from your Pokedex",
-			callback:    commands.InspectFunc(trainer),
-		},
-		"map": {
-			description: "Display the next 20 locations in the Pokemon world",
-			callback:    commands.MapFunc(client, trainer),
-		},
-		"mapb": {
-			description: "Display the previous 20 locations in the Pokemon world",
-			callback:    commands.MapBFunc(client, trainer),
-		},
-		"pokedex": {
-			description: "List the names of all the Pokemon in your Pokedex",
-			callback:    commands.PokedexFunc(trainer),
-		},
-		"release": {
-			description: "Release a Pokemon back into the wild",
-			callback:    commands.ReleaseFunc(trainer),
-		},
-		"visit": {
-			description: "Visit a location area",
-			callback:    commands.VisitFunc(client, trainer),
-		},
-	}
+var errCancelled = errors.New("the command was cancelled")
 
-	summaries := summaryMap(commandMap)
+func repl(application *app) {
+	scanner := bufio.NewScanner(os.Stdin)
 
-	commandMap["help"] = command{
-		description: "Displays a help message",
-		callback:    commands.HelpFunc(summaries),
-	}
+	// loopFunc runs the commands on the current line and returns false
+	// when the trainer has asked to exit.
+	loopFunc := func() bool {
+		line := scanner.Text()
 
-	scanner := bufio.NewScanner(os.Stdin)
+		pipelines, err := cmdline.Parse(line)
+		if err != nil {
+			application.printError(os.Stdout, err)
 
-	loopFunc := func() {
-		defer printPrompt()
+			return true
+		}
 
-		input := scanner.Text()
+		recordingBefore, _ := application.shortcuts.Recording()
+		failed := false
 
-		command, args := parseInput(input)
+		for _, pipeline := range slices.All(pipelines) {
+			if err := application.run(pipeline); err != nil {
+				if errors.Is(err, commands.ErrExit) {
+					return false
+				}
 
-		cmd, ok := commandMap[command]
-		if !ok {
-			fmt.Println("ERROR: Unrecognised command.")
+				if errors.Is(err, errCancelled) {
+					fmt.Println("\nThe command was cancelled.")
 
-			return
-		}
+					return true
+				}
 
-		if cmd.callback == nil {
-			fmt.Println("ERROR: This command is defined but does not have a callback function.")
+				application.printError(os.Stdout, err)
 
-			return
+				failed = true
+			}
 		}
 
-		if err := commandMap[command].callback(args); err != nil {
-			fmt.Printf("ERROR: %v.\n", err)
+		// Only the lines that run without errors are recorded. The lines that
+		// start or stop the recording are not part of the macro.
+		recordingAfter, _ := application.shortcuts.Recording()
 
-			return
+		if !failed && len(pipelines) > 0 && recordingBefore != "" && recordingBefore == recordingAfter {
+			application.shortcuts.Record(line)
 		}
+
+		return true
 	}
 
 	fmt.Printf("\nWelcome to the Pokemon world!\n")
 	printPrompt()
 
 	for scanner.Scan() {
-		loopFunc()
-	}
-}
-
-func parseInput(input string) (string, []string) {
-	input = strings.TrimSpace(input)
-	input = strings.ToLower(input)
-	split := strings.Split(input, " ")
-
-	if len(split) == 0 {
-		return "", nil
-	}
+		if !loopFunc() {
+			return
+		}
 
-	if len(split) == 1 {
-		return split[0], nil
+		printPrompt()
 	}
-
-	return split[0], split[1:]
 }
 
 func printPrompt() {
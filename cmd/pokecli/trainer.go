@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+const (
+	appName           = "pokecli"
+	eventLogFilename  = "trainer.jsonl"
+	shortcutsFilename = "shortcuts.json"
+	spritesDirname    = "sprites"
+)
+
+// configDir returns the path to the application's configuration directory,
+// creating it if it does not exist.
+func configDir() (string, error) {
+	userConfigDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the user's configuration directory: %w", err)
+	}
+
+	dir := filepath.Join(userConfigDir, appName)
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("unable to create %s: %w", dir, err)
+	}
+
+	return dir, nil
+}
+
+// loadTrainer rebuilds the trainer from the event log and sets the log as the
+// destination for all future events. The returned file should be closed
+// when the application exits.
+func loadTrainer(eventLogPath string) (*poketrainer.Trainer, *os.File, error) {
+	file, err := os.OpenFile(eventLogPath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open the event log: %w", err)
+	}
+
+	trainer, err := poketrainer.LoadTrainer(file)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, nil, fmt.Errorf("unable to load the trainer from %s: %w", eventLogPath, err)
+	}
+
+	trainer.SetEventLog(file)
+
+	return trainer, file, nil
+}
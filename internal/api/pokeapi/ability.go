@@ -0,0 +1,42 @@
+package pokeapi
+
+// Ability provides a passive effect for a Pokemon in battle or in the overworld.
+type Ability struct {
+	ID                int                   `json:"id"`
+	Name              string                `json:"name"`
+	IsMainSeries      bool                  `json:"is_main_series"`
+	Generation        NamedAPIResource      `json:"generation"`
+	Names             []Name                `json:"names"`
+	EffectEntries     []VerboseEffect       `json:"effect_entries"`
+	EffectChanges     []AbilityEffectChange `json:"effect_changes"`
+	FlavorTextEntries []AbilityFlavorText   `json:"flavor_text_entries"`
+	Pokemon           []AbilityPokemon      `json:"pokemon"`
+}
+
+type VerboseEffect struct {
+	Effect      string           `json:"effect"`
+	ShortEffect string           `json:"short_effect"`
+	Language    NamedAPIResource `json:"language"`
+}
+
+type Effect struct {
+	Effect   string           `json:"effect"`
+	Language NamedAPIResource `json:"language"`
+}
+
+type AbilityEffectChange struct {
+	EffectEntries []Effect         `json:"effect_entries"`
+	VersionGroup  NamedAPIResource `json:"version_group"`
+}
+
+type AbilityFlavorText struct {
+	FlavorText   string           `json:"flavor_text"`
+	Language     NamedAPIResource `json:"language"`
+	VersionGroup NamedAPIResource `json:"version_group"`
+}
+
+type AbilityPokemon struct {
+	IsHidden bool             `json:"is_hidden"`
+	Slot     int              `json:"slot"`
+	Pokemon  NamedAPIResource `json:"pokemon"`
+}
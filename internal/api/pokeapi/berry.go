@@ -0,0 +1,23 @@
+package pokeapi
+
+// Berry is a small fruit that can provide HP and status condition restoration,
+// stat enhancement, and even damage negation when eaten by Pokemon.
+type Berry struct {
+	ID               int              `json:"id"`
+	Name             string           `json:"name"`
+	GrowthTime       int              `json:"growth_time"`
+	MaxHarvest       int              `json:"max_harvest"`
+	NaturalGiftPower int              `json:"natural_gift_power"`
+	Size             int              `json:"size"`
+	Smoothness       int              `json:"smoothness"`
+	SoilDryness      int              `json:"soil_dryness"`
+	Firmness         NamedAPIResource `json:"firmness"`
+	Flavors          []BerryFlavorMap `json:"flavors"`
+	Item             NamedAPIResource `json:"item"`
+	NaturalGiftType  NamedAPIResource `json:"natural_gift_type"`
+}
+
+type BerryFlavorMap struct {
+	Potency int              `json:"potency"`
+	Flavor  NamedAPIResource `json:"flavor"`
+}
@@ -0,0 +1,38 @@
+package pokeapi
+
+// Item is an object in the games which the player can pick up, keep in their
+// bag, and use in some manner.
+type Item struct {
+	ID                int                 `json:"id"`
+	Name              string              `json:"name"`
+	Cost              int                 `json:"cost"`
+	FlingPower        *int                `json:"fling_power"`
+	FlingEffect       *NamedAPIResource   `json:"fling_effect"`
+	Attributes        []NamedAPIResource  `json:"attributes"`
+	Category          NamedAPIResource    `json:"category"`
+	EffectEntries     []VerboseEffect     `json:"effect_entries"`
+	FlavorTextEntries []ItemFlavorText    `json:"flavor_text_entries"`
+	Names             []Name              `json:"names"`
+	Sprites           ItemSprites         `json:"sprites"`
+	HeldByPokemon     []ItemHolderPokemon `json:"held_by_pokemon"`
+}
+
+type ItemFlavorText struct {
+	Text         string           `json:"text"`
+	Language     NamedAPIResource `json:"language"`
+	VersionGroup NamedAPIResource `json:"version_group"`
+}
+
+type ItemSprites struct {
+	Default string `json:"default"`
+}
+
+type ItemHolderPokemon struct {
+	Pokemon        NamedAPIResource                 `json:"pokemon"`
+	VersionDetails []ItemHolderPokemonVersionDetail `json:"version_details"`
+}
+
+type ItemHolderPokemonVersionDetail struct {
+	Rarity  int              `json:"rarity"`
+	Version NamedAPIResource `json:"version"`
+}
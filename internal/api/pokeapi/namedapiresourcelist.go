@@ -11,3 +11,7 @@ type NamedAPIResource struct {
 	Name string `json:"name"`
 	URL  string `json:"url"`
 }
+
+type APIResource struct {
+	URL string `json:"url"`
+}
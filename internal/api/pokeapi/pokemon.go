@@ -51,7 +51,7 @@ type PokemonMoves struct {
 type PokemonMoveVersion struct {
 	MoveLearnMethod NamedAPIResource `json:"move_learn_method"`
 	VersionGroup    NamedAPIResource `json:"version_group"`
-	LevelLearnedAt  int
+	LevelLearnedAt  int              `json:"level_learned_at"`
 }
 
 type PokemonTypePast struct {
@@ -0,0 +1,32 @@
+package pokeapi
+
+// PokemonSpecies forms the basis for at least one Pokemon.
+type PokemonSpecies struct {
+	ID                   int                     `json:"id"`
+	Name                 string                  `json:"name"`
+	Order                int                     `json:"order"`
+	GenderRate           int                     `json:"gender_rate"`
+	CaptureRate          int                     `json:"capture_rate"`
+	BaseHappiness        int                     `json:"base_happiness"`
+	IsBaby               bool                    `json:"is_baby"`
+	IsLegendary          bool                    `json:"is_legendary"`
+	IsMythical           bool                    `json:"is_mythical"`
+	HatchCounter         int                     `json:"hatch_counter"`
+	HasGenderDifferences bool                    `json:"has_gender_differences"`
+	FormsSwitchable      bool                    `json:"forms_switchable"`
+	GrowthRate           NamedAPIResource        `json:"growth_rate"`
+	EggGroups            []NamedAPIResource      `json:"egg_groups"`
+	Color                NamedAPIResource        `json:"color"`
+	Shape                NamedAPIResource        `json:"shape"`
+	EvolvesFromSpecies   *NamedAPIResource       `json:"evolves_from_species"`
+	EvolutionChain       APIResource             `json:"evolution_chain"`
+	Habitat              *NamedAPIResource       `json:"habitat"`
+	Generation           NamedAPIResource        `json:"generation"`
+	Names                []Name                  `json:"names"`
+	Varieties            []PokemonSpeciesVariety `json:"varieties"`
+}
+
+type PokemonSpeciesVariety struct {
+	IsDefault bool             `json:"is_default"`
+	Pokemon   NamedAPIResource `json:"pokemon"`
+}
@@ -0,0 +1,28 @@
+package pokeapi
+
+// Type is an elemental type of Pokemon and moves. The damage relations
+// describe how effective its moves are against the other types.
+type Type struct {
+	ID              int                `json:"id"`
+	Name            string             `json:"name"`
+	DamageRelations TypeRelations      `json:"damage_relations"`
+	Generation      NamedAPIResource   `json:"generation"`
+	MoveDamageClass NamedAPIResource   `json:"move_damage_class"`
+	Names           []Name             `json:"names"`
+	Pokemon         []TypePokemon      `json:"pokemon"`
+	Moves           []NamedAPIResource `json:"moves"`
+}
+
+type TypeRelations struct {
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+}
+
+type TypePokemon struct {
+	Slot    int              `json:"slot"`
+	Pokemon NamedAPIResource `json:"pokemon"`
+}
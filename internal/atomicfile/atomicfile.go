@@ -0,0 +1,48 @@
+// Package atomicfile writes files so that they are never left half written.
+package atomicfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Write writes the data to a temporary file in the same directory as the
+// path, flushes it to the disk and then renames it over the file at the path.
+// A reader therefore sees either the old contents or the new contents, even
+// if the application or the system crashes while the file is being written.
+// The directory is created if it does not exist.
+func Write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("unable to create the directory: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to create the temporary file: %w", err)
+	}
+
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		_ = tempFile.Close()
+
+		return fmt.Errorf("unable to write the temporary file: %w", err)
+	}
+
+	if err := tempFile.Sync(); err != nil {
+		_ = tempFile.Close()
+
+		return fmt.Errorf("unable to flush the temporary file to the disk: %w", err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("unable to close the temporary file: %w", err)
+	}
+
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("unable to replace the file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package atomicfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/atomicfile"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pokecli", "shortcuts.json")
+
+	for _, data := range []string{`{"aliases": {}}`, `{"macros": {}}`} {
+		if err := atomicfile.Write(path, []byte(data)); err != nil {
+			t.Fatalf("Unable to write the file: %v", err)
+		}
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unable to read the file: %v", err)
+		}
+
+		if string(got) != data {
+			t.Errorf("Unexpected file contents: want %q, got %q", data, got)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("Unable to read the directory: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("Unexpected files left in the directory: want 1, got %d", len(entries))
+	}
+}
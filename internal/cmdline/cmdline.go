@@ -0,0 +1,167 @@
+// Package cmdline splits a line of input into commands the way a shell does.
+//
+// Words are separated by whitespace. Single quotes preserve everything between
+// them, double quotes preserve everything except backslash escapes and a
+// backslash outside of quotes escapes the next character. A semicolon separates
+// commands on the same line and a pipe sends the output of a command into the
+// next stage of the pipeline.
+package cmdline
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrUnterminatedQuote  = errors.New("unterminated quote")
+	ErrUnfinishedEscape   = errors.New("unfinished escape at the end of the line")
+	ErrEmptyPipelineStage = errors.New("missing command in the pipeline")
+)
+
+// Pipeline is a command followed by the stages that its output is piped into.
+// Each stage is a list of words.
+type Pipeline [][]string
+
+// Parse splits the line into pipelines. Empty commands between semicolons
+// are ignored.
+func Parse(line string) ([]Pipeline, error) {
+	var (
+		pipelines []Pipeline
+		pipeline  Pipeline
+		stage     []string
+		word      strings.Builder
+		inWord    bool
+		quote     rune
+		escaped   bool
+		piped     bool
+	)
+
+	endWord := func() {
+		if inWord {
+			stage = append(stage, word.String())
+			word.Reset()
+
+			inWord = false
+		}
+	}
+
+	endStage := func() error {
+		endWord()
+
+		if len(stage) == 0 {
+			return ErrEmptyPipelineStage
+		}
+
+		pipeline = append(pipeline, stage)
+		stage = nil
+
+		return nil
+	}
+
+	endPipeline := func() error {
+		endWord()
+
+		if len(stage) == 0 && !piped {
+			return nil
+		}
+
+		if err := endStage(); err != nil {
+			return err
+		}
+
+		pipelines = append(pipelines, pipeline)
+		pipeline = nil
+		piped = false
+
+		return nil
+	}
+
+	for _, char := range line {
+		switch {
+		case escaped:
+			word.WriteRune(char)
+
+			escaped = false
+		case quote == '\'':
+			if char == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(char)
+			}
+		case quote == '"':
+			switch char {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				word.WriteRune(char)
+			}
+		case char == '\'' || char == '"':
+			quote = char
+			inWord = true
+		case char == '\\':
+			escaped = true
+			inWord = true
+		case char == '|':
+			if err := endStage(); err != nil {
+				return nil, err
+			}
+
+			piped = true
+		case char == ';':
+			if err := endPipeline(); err != nil {
+				return nil, err
+			}
+		case unicode.IsSpace(char):
+			endWord()
+		default:
+			word.WriteRune(char)
+
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, ErrUnterminatedQuote
+	}
+
+	if escaped {
+		return nil, ErrUnfinishedEscape
+	}
+
+	if err := endPipeline(); err != nil {
+		return nil, err
+	}
+
+	return pipelines, nil
+}
+
+// Join joins the words into a line that Parse splits back into the same words,
+// quoting the words that contain whitespace, quotes or separators.
+func Join(words []string) string {
+	quoted := make([]string, len(words))
+
+	for ind, word := range slices.All(words) {
+		quoted[ind] = Quote(word)
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// Quote returns the word in single quotes if it needs to be quoted.
+func Quote(word string) string {
+	if word == "" {
+		return "''"
+	}
+
+	if !strings.ContainsFunc(word, func(char rune) bool {
+		return unicode.IsSpace(char) || strings.ContainsRune(`'"\|;`, char)
+	}) {
+		return word
+	}
+
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
@@ -0,0 +1,128 @@
+package cmdline_test
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/cmdline"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  []cmdline.Pipeline
+	}{
+		{
+			name:  "Empty line",
+			input: "   ",
+			want:  nil,
+		},
+		{
+			name:  "Repeated spaces",
+			input: "  catch   pikachu  ",
+			want:  []cmdline.Pipeline{{{"catch", "pikachu"}}},
+		},
+		{
+			name:  "Quoted words",
+			input: `catch "mr. mime" 'farfetch"d'`,
+			want:  []cmdline.Pipeline{{{"catch", "mr. mime", `farfetch"d`}}},
+		},
+		{
+			name:  "Escapes",
+			input: `catch mr.\ mime "say \"hi\"" \;`,
+			want:  []cmdline.Pipeline{{{"catch", "mr. mime", `say "hi"`, ";"}}},
+		},
+		{
+			name:  "Empty quoted word",
+			input: `grep ""`,
+			want:  []cmdline.Pipeline{{{"grep", ""}}},
+		},
+		{
+			name:  "Multiple commands",
+			input: "map; visit iron-island-area;; explore",
+			want: []cmdline.Pipeline{
+				{{"map"}},
+				{{"visit", "iron-island-area"}},
+				{{"explore"}},
+			},
+		},
+		{
+			name:  "Pipelines",
+			input: "pokedex | grep fire; explore|count",
+			want: []cmdline.Pipeline{
+				{{"pokedex"}, {"grep", "fire"}},
+				{{"explore"}, {"count"}},
+			},
+		},
+		{
+			name:  "Quoted separators",
+			input: `grep "a | b; c"`,
+			want:  []cmdline.Pipeline{{{"grep", "a | b; c"}}},
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := cmdline.Parse(testcase.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, testcase.want) {
+				t.Errorf("Unexpected pipelines: want %q, got %q", testcase.want, got)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		input string
+		want  error
+	}{
+		{input: `catch "pikachu`, want: cmdline.ErrUnterminatedQuote},
+		{input: `catch 'pikachu`, want: cmdline.ErrUnterminatedQuote},
+		{input: `catch pikachu\`, want: cmdline.ErrUnfinishedEscape},
+		{input: "| grep fire", want: cmdline.ErrEmptyPipelineStage},
+		{input: "pokedex |", want: cmdline.ErrEmptyPipelineStage},
+		{input: "pokedex | | count", want: cmdline.ErrEmptyPipelineStage},
+		{input: "pokedex |; count", want: cmdline.ErrEmptyPipelineStage},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		if _, err := cmdline.Parse(testcase.input); !errors.Is(err, testcase.want) {
+			t.Errorf("Unexpected error after parsing %q: want %v, got %v", testcase.input, testcase.want, err)
+		}
+	}
+}
+
+func TestJoin(t *testing.T) {
+	cases := []struct {
+		words []string
+		want  string
+	}{
+		{words: []string{"visit", "iron-island-area"}, want: "visit iron-island-area"},
+		{words: []string{"catch", "mr. mime"}, want: "catch 'mr. mime'"},
+		{words: []string{"release", "farfetch'd"}, want: `release 'farfetch'\''d'`},
+		{words: []string{"grep", "a|b", ""}, want: "grep 'a|b' ''"},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		got := cmdline.Join(testcase.words)
+		if got != testcase.want {
+			t.Errorf("Unexpected line after joining %q: want %q, got %q", testcase.words, testcase.want, got)
+		}
+
+		pipelines, err := cmdline.Parse(got)
+		if err != nil {
+			t.Fatalf("Unable to parse %q: %v", got, err)
+		}
+
+		if want := []cmdline.Pipeline{{testcase.words}}; !reflect.DeepEqual(pipelines, want) {
+			t.Errorf("Unexpected words after parsing %q: want %q, got %q", got, want, pipelines)
+		}
+	}
+}
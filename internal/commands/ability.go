@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func AbilityCommand(client *pokeclient.Client) *Command {
+	return &Command{
+		Name:    "ability",
+		Summary: "Display the effect of an ability and the Pokemon that can have it",
+		Help: "Displays the effect of an ability and lists the Pokemon that can have it. " +
+			"The Pokemon that can only have it as a hidden ability are marked as hidden.",
+		Args: []Arg{{Name: "ability", Description: "name of the ability", Normalise: resourceName}},
+		Run:  abilityFunc(client),
+	}
+}
+
+func abilityFunc(client *pokeclient.Client) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		abilityName := input.Arg(0)
+
+		ability, err := client.GetAbility(ctx, abilityName)
+		if err != nil {
+			return describeRequestError(
+				err,
+				"ability",
+				abilityName,
+				"unable to get the information on "+abilityName,
+				resourceSuggester(ctx, client, pokeclient.AbilityResource),
+			)
+		}
+
+		var builder strings.Builder
+
+		fmt.Fprintf(&builder, "Name: %s\nGeneration: %s\n", ability.Name, ability.Generation.Name)
+
+		effect, shortEffect := abilityEffect(ability)
+		writeEffect(&builder, effect, shortEffect)
+
+		if len(ability.Pokemon) == 0 {
+			builder.WriteString("No Pokemon can have this ability.\n")
+		} else {
+			builder.WriteString("Pokemon with this ability:\n")
+
+			for _, pokemon := range slices.All(ability.Pokemon) {
+				builder.WriteString("  - " + pokemon.Pokemon.Name)
+
+				if pokemon.IsHidden {
+					builder.WriteString(" (hidden)")
+				}
+
+				builder.WriteString("\n")
+			}
+		}
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+// abilityEffect returns the effect and short effect of the ability in English.
+// The abilities that do not have an effect entry are described by their latest
+// flavour text instead.
+func abilityEffect(ability pokeapi.Ability) (string, string) {
+	if effect, shortEffect, ok := englishEffect(ability.EffectEntries); ok {
+		return effect, shortEffect
+	}
+
+	for _, entry := range slices.Backward(ability.FlavorTextEntries) {
+		if entry.Language.Name == language {
+			return "", flattenText(entry.FlavorText)
+		}
+	}
+
+	return "", ""
+}
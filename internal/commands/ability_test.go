@@ -0,0 +1,88 @@
+package commands_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/commands"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func TestAbility(t *testing.T) {
+	server := pokeapitest.NewServer(t)
+	server.HandleJSON(
+		"/api/v2/ability/lightning-rod/",
+		`{
+			"name": "lightning-rod",
+			"generation": {"name": "generation-iii"},
+			"effect_entries": [
+				{"effect": "Bewirkt etwas.", "short_effect": "Etwas.", "language": {"name": "de"}},
+				{"effect": "Redirects single-target electric moves.\n\nThis also raises Special Attack.", "short_effect": "Draws in electric moves.", "language": {"name": "en"}}
+			],
+			"pokemon": [{"is_hidden": true, "pokemon": {"name": "pikachu"}}, {"is_hidden": false, "pokemon": {"name": "cubone"}}]
+		}`,
+	)
+	server.HandleJSON(
+		"/api/v2/ability/stench/",
+		`{
+			"name": "stench",
+			"generation": {"name": "generation-iii"},
+			"flavor_text_entries": [
+				{"flavor_text": "Helps repel\nwild POKéMON.", "language": {"name": "en"}},
+				{"flavor_text": "The stench may\ncause the target\fto flinch.", "language": {"name": "en"}}
+			]
+		}`,
+	)
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	registry := commands.NewRegistry()
+	if err := registry.Register(commands.AbilityCommand(client)); err != nil {
+		t.Fatalf("Unable to register the ability command: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "Effect entries",
+			args: []string{"ability", "Lightning Rod"},
+			want: []string{
+				"Short effect: Draws in electric moves.\n",
+				"Effect:\n  Redirects single-target electric moves.\n\n  This also raises Special Attack.\n",
+				"  - pikachu (hidden)\n  - cubone\n",
+			},
+		},
+		{
+			name: "Flavour text",
+			args: []string{"ability", "stench"},
+			want: []string{
+				"Short effect: The stench may cause the target to flinch.\n",
+				"No Pokemon can have this ability.\n",
+			},
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			var output strings.Builder
+
+			if err := registry.Run(context.Background(), &output, testcase.args); err != nil {
+				t.Fatalf("Unable to run %v: %v", testcase.args, err)
+			}
+
+			for _, want := range slices.All(testcase.want) {
+				if !strings.Contains(output.String(), want) {
+					t.Errorf("The output does not contain %q:\n%s", want, output.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func AchievementsCommand(trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "achievements",
+		Summary: "List the achievements and the ones you've unlocked",
+		Help:    "Lists every achievement along with its description. The achievements that you've unlocked are marked with an x.",
+		Run:     achievementsFunc(trainer),
+	}
+}
+
+func achievementsFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		achievements, unlocked := trainer.Achievements()
+
+		var builder strings.Builder
+
+		builder.WriteString("Achievements:\n")
+
+		for _, achievement := range slices.All(achievements) {
+			mark := " "
+			if unlocked[achievement.ID] {
+				mark = "x"
+			}
+
+			fmt.Fprintf(&builder, "  [%s] %s: %s\n", mark, achievement.Name, achievement.Description)
+		}
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+func printNewAchievements(out io.Writer, trainer *poketrainer.Trainer) {
+	for _, achievement := range slices.All(trainer.NewAchievements()) {
+		fmt.Fprintf(out, "Achievement unlocked: %s! (%s)\n", achievement.Name, achievement.Description)
+	}
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func BerryCommand(client *pokeclient.Client) *Command {
+	return &Command{
+		Name:    "berry",
+		Summary: "Display the details of a berry",
+		Help:    "Displays how a berry grows, its flavours, the type and power of Natural Gift when it is held and its effect.",
+		Args:    []Arg{{Name: "berry", Description: "name of the berry", Normalise: berryName}},
+		Run:     berryFunc(client),
+	}
+}
+
+// berryName converts the name of a berry typed by the user into the name of
+// the berry resource, which drops the "berry" suffix of the item's name.
+func berryName(name string) string {
+	return strings.TrimSuffix(resourceName(name), "-berry")
+}
+
+func berryFunc(client *pokeclient.Client) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		name := input.Arg(0)
+
+		berry, err := client.GetBerry(ctx, name)
+		if err != nil {
+			return describeRequestError(
+				err,
+				"berry",
+				name,
+				"unable to get the information on the "+name+" berry",
+				resourceSuggester(ctx, client, pokeclient.BerryResource),
+			)
+		}
+
+		item, err := client.GetItem(ctx, berry.Item.Name)
+		if err != nil {
+			return describeRequestError(
+				err,
+				"item",
+				berry.Item.Name,
+				"unable to get the information on "+berry.Item.Name,
+				nil,
+			)
+		}
+
+		var builder strings.Builder
+
+		fmt.Fprintf(
+			&builder,
+			"Name: %s\nItem: %s\nFirmness: %s\nSize: %d mm\nSmoothness: %d\n",
+			berry.Name,
+			berry.Item.Name,
+			berry.Firmness.Name,
+			berry.Size,
+			berry.Smoothness,
+		)
+
+		fmt.Fprintf(
+			&builder,
+			"Growth time: %d hours per stage\nMaximum harvest: %d\nSoil dryness: %d\n",
+			berry.GrowthTime,
+			berry.MaxHarvest,
+			berry.SoilDryness,
+		)
+
+		fmt.Fprintf(&builder, "Natural Gift: %s (power %d)\n", berry.NaturalGiftType.Name, berry.NaturalGiftPower)
+
+		builder.WriteString("Flavours:")
+
+		flavours := 0
+
+		for _, flavour := range slices.All(berry.Flavors) {
+			if flavour.Potency == 0 {
+				continue
+			}
+
+			fmt.Fprintf(&builder, "\n  - %s: %d", flavour.Flavor.Name, flavour.Potency)
+
+			flavours++
+		}
+
+		if flavours == 0 {
+			builder.WriteString(" none")
+		}
+
+		builder.WriteString("\n")
+
+		effect, shortEffect := itemEffect(item)
+		writeEffect(&builder, effect, shortEffect)
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
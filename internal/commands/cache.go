@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func CacheCommand(client *pokeclient.Client) *Command {
+	return &Command{
+		Name:    "cache",
+		Summary: "Manage the cache of responses from the PokeAPI",
+		Help:    "The responses from the PokeAPI are cached in memory to reduce the number of requests sent to the server.",
+		Subcommands: []*Command{
+			{
+				Name:    "stats",
+				Summary: "Display the cache statistics",
+				Run:     cacheStatsFunc(client),
+			},
+			{
+				Name:    "list",
+				Summary: "List the keys of the cached entries",
+				Run:     cacheListFunc(client),
+			},
+			{
+				Name:    "clear",
+				Summary: "Remove all the entries from the cache",
+				Run:     cacheClearFunc(client),
+			},
+			{
+				Name:    "purge",
+				Summary: "Remove an entry from the cache",
+				Args:    []Arg{{Name: "key", Description: "key of the cache entry"}},
+				Run:     cachePurgeFunc(client),
+			},
+		},
+	}
+}
+
+func cacheClearFunc(client *pokeclient.Client) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		client.ClearCache()
+
+		fmt.Fprintln(out, "The cache has been cleared.")
+
+		return nil
+	}
+}
+
+func cachePurgeFunc(client *pokeclient.Client) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		key := input.Arg(0)
+
+		if !client.PurgeCacheEntry(key) {
+			return fmt.Errorf("%s is not in the cache", key)
+		}
+
+		fmt.Fprintf(out, "%s has been removed from the cache.\n", key)
+
+		return nil
+	}
+}
+
+func cacheStatsFunc(client *pokeclient.Client) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		stats := client.CacheStats()
+
+		var builder strings.Builder
+
+		builder.WriteString("Cache statistics:\n")
+
+		tableWriter := tabwriter.NewWriter(&builder, 0, 8, 1, ' ', 0)
+
+		fmt.Fprintf(tableWriter, "  Hits:\t%d\n", stats.Hits)
+		fmt.Fprintf(tableWriter, "  Misses:\t%d\n", stats.Misses)
+		fmt.Fprintf(tableWriter, "  Evictions:\t%d\n", stats.Evictions)
+		fmt.Fprintf(tableWriter, "  Expirations:\t%d\n", stats.Expirations)
+		fmt.Fprintf(tableWriter, "  Entries:\t%s\n", withLimit(stats.Entries, stats.MaxEntries, ""))
+		fmt.Fprintf(tableWriter, "  Size:\t%s\n", withLimit(stats.Bytes, stats.MaxBytes, " bytes"))
+
+		tableWriter.Flush()
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+func cacheListFunc(client *pokeclient.Client) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		keys := client.CacheKeys()
+
+		if len(keys) == 0 {
+			fmt.Fprintln(out, "The cache is empty.")
+
+			return nil
+		}
+
+		var builder strings.Builder
+
+		builder.WriteString("Cached entries:\n")
+
+		for _, key := range slices.All(keys) {
+			builder.WriteString("  - " + key + "\n")
+		}
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+func withLimit(value, limit int, unit string) string {
+	if limit <= 0 {
+		return fmt.Sprintf("%d%s (unlimited)", value, unit)
+	}
+
+	return fmt.Sprintf("%d/%d%s", value, limit, unit)
+}
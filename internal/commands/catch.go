@@ -1,29 +1,31 @@
 package commands
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"io"
 	"math/rand/v2"
 	"slices"
 
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
 )
 
-func CatchFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
-	return func(args []string) error {
-		if args == nil {
-			return errors.New("the name of the Pokemon has not been specified")
-		}
-
-		if len(args) != 1 {
-			return fmt.Errorf(
-				"unexpected number of Pokemon names: want 1; got %d",
-				len(args),
-			)
-		}
+func CatchCommand(client *pokeclient.Client, trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "catch",
+		Summary: "Catch a Pokemon and add it to your Pokedex",
+		Help: "Throws a Pokeball at a Pokemon that can be found in the location area that you are visiting. " +
+			"You have a 50% chance of catching it. A Pokemon that can hold items in the wild may be holding one when it is caught.",
+		Args: []Arg{pokemonArg},
+		Run:  catchFunc(client, trainer),
+	}
+}
 
-		pokemonName := args[0]
+func catchFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		pokemonName := input.Arg(0)
 
 		if _, caught := trainer.GetPokemonFromPokedex(pokemonName); caught {
 			return fmt.Errorf(
@@ -32,18 +34,20 @@ func CatchFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandF
 			)
 		}
 
-		pokemonDetails, err := client.GetPokemon(pokemonName)
+		pokemonDetails, err := client.GetPokemon(ctx, pokemonName)
 		if err != nil {
-			return fmt.Errorf(
-				"unable to get the information on %s: %w",
-				pokemonName,
+			return describeRequestError(
 				err,
+				"Pokemon",
+				pokemonName,
+				"unable to get the information on "+pokemonName,
+				resourceSuggester(ctx, client, pokeclient.PokemonResource),
 			)
 		}
 
 		encountersPath := pokemonDetails.LocationAreaEncounters
 
-		encounterAreas, err := client.GetPokemonLocationAreas(encountersPath)
+		encounterAreas, err := client.GetPokemonLocationAreas(ctx, encountersPath)
 		if err != nil {
 			return fmt.Errorf(
 				"unable to get the Pokemon's possible encounter areas: %w",
@@ -72,19 +76,55 @@ func CatchFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandF
 
 		chance := 50
 
-		fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
+		fmt.Fprintf(out, "Throwing a Pokeball at %s...\n", pokemonName)
 
 		if caught := success(chance); caught {
-			trainer.AddPokemonToPokedex(pokemonName, pokemonDetails)
-			fmt.Printf("%s was caught!\nYou may now inspect it with the inspect command.\n", pokemonName)
+			heldItem := wildHeldItem(pokemonDetails)
+
+			if err := trainer.AddPokemonHoldingItem(pokemonName, pokemonDetails, heldItem); err != nil {
+				return fmt.Errorf("unable to add %s to the Pokedex: %w", pokemonName, err)
+			}
+
+			fmt.Fprintf(out, "%s was caught!\n", pokemonName)
+
+			if heldItem != "" {
+				fmt.Fprintf(out, "It was holding %s!\n", heldItem)
+			}
+
+			fmt.Fprintln(out, "You may now inspect it with the inspect command.")
 		} else {
-			fmt.Printf("%s escaped!\n", pokemonName)
+			if err := trainer.RecordEscape(pokemonName); err != nil {
+				return fmt.Errorf("unable to record the escape: %w", err)
+			}
+
+			fmt.Fprintf(out, "%s escaped!\n", pokemonName)
 		}
 
+		printNewAchievements(out, trainer)
+
 		return nil
 	}
 }
 
+// wildHeldItem rolls for the item that the wild Pokemon is holding, if any.
+// The chance of holding each item is its highest rarity across the versions
+// of the games.
+func wildHeldItem(pokemon pokeapi.Pokemon) string {
+	for _, heldItem := range slices.All(pokemon.HeldItems) {
+		chance := 0
+
+		for _, details := range slices.All(heldItem.VersionDetails) {
+			chance = max(chance, details.Rarity)
+		}
+
+		if success(chance) {
+			return heldItem.Item.Name
+		}
+	}
+
+	return ""
+}
+
 func success(chance int) bool {
 	if chance >= 100 {
 		return true
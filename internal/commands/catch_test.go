@@ -0,0 +1,94 @@
+package commands_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/commands"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func TestSecondCatchAttemptUsesCache(t *testing.T) {
+	server := pokeapitest.NewServer(t)
+	server.HandleJSON(
+		"/api/v2/pokemon/pikachu/",
+		`{"id": 25, "name": "pikachu", "location_area_encounters": "`+pokeapitest.BaseURLPlaceholder+`/api/v2/pokemon/25/encounters"}`,
+	)
+	server.HandleJSON(
+		"/api/v2/pokemon/25/encounters",
+		`[{"location_area": {"name": "viridian-forest-area", "url": ""}, "version_details": []}]`,
+	)
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	trainer := poketrainer.NewTrainer()
+
+	if err := trainer.UpdateCurrentLocationArea(pokeapi.LocationArea{Name: "viridian-forest-area"}); err != nil {
+		t.Fatalf("Unable to update the trainer's location: %v", err)
+	}
+
+	registry := commands.NewRegistry()
+	if err := registry.Register(commands.CatchCommand(client, trainer)); err != nil {
+		t.Fatalf("Unable to register the catch command: %v", err)
+	}
+
+	catch := []string{"catch", "pikachu"}
+
+	if err := registry.Run(context.Background(), io.Discard, catch); err != nil {
+		t.Fatalf("Unable to catch pikachu: %v", err)
+	}
+
+	if got := server.TotalRequests(); got != 2 {
+		t.Fatalf("Unexpected number of requests after the first catch attempt: want 2, got %d", got)
+	}
+
+	// Release pikachu if it was caught so that the second attempt goes through
+	// the whole catch process again.
+	if _, caught := trainer.GetPokemonFromPokedex("pikachu"); caught {
+		if err := trainer.RemovePokemonFromPokedex("pikachu"); err != nil {
+			t.Fatalf("Unable to release pikachu: %v", err)
+		}
+	}
+
+	if err := registry.Run(context.Background(), io.Discard, catch); err != nil {
+		t.Fatalf("Unable to catch pikachu: %v", err)
+	}
+
+	if got := server.TotalRequests(); got != 2 {
+		t.Errorf("Unexpected number of requests after the second catch attempt: want 2 (no new requests), got %d", got)
+	}
+}
+
+func TestCatchSuggestsNames(t *testing.T) {
+	server := pokeapitest.NewServer(t)
+	server.HandleJSON(
+		"/api/v2/pokemon",
+		`{"count": 3, "results": [{"name": "pikachu"}, {"name": "pichu"}, {"name": "raichu"}]}`,
+	)
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	trainer := poketrainer.NewTrainer()
+
+	registry := commands.NewRegistry()
+	if err := registry.Register(commands.CatchCommand(client, trainer)); err != nil {
+		t.Fatalf("Unable to register the catch command: %v", err)
+	}
+
+	err := registry.Run(context.Background(), io.Discard, []string{"catch", "pikachuu"})
+	if err == nil {
+		t.Fatal("Expected an error after trying to catch a Pokemon that does not exist")
+	}
+
+	want := "no Pokemon called 'pikachuu' (did you mean pikachu?)"
+	if got := err.Error(); got != want {
+		t.Errorf("Unexpected error message: want %q, got %q", want, got)
+	}
+}
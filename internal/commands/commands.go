@@ -1,3 +1,32 @@
 package commands
 
-type CommandFunc func(args []string) error
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+type CommandFunc func(ctx context.Context, out io.Writer, input Input) error
+
+// pokemonArg is the argument for the name of a Pokemon.
+var pokemonArg = Arg{Name: "pokemon", Description: "name of the Pokemon", Normalise: resourceName}
+
+var resourceNameReplacer = strings.NewReplacer(
+	".", "",
+	"'", "",
+	"’", "",
+	":", "",
+	"é", "e",
+	"♀", "-f",
+	"♂", "-m",
+)
+
+// resourceName converts a name typed by the user into the name of the resource
+// in the PokeAPI, e.g. "Mr. Mime" into "mr-mime" and "Farfetch'd" into "farfetchd".
+func resourceName(name string) string {
+	name = resourceNameReplacer.Replace(strings.ToLower(name))
+
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "-")
+}
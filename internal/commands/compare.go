@@ -0,0 +1,335 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func CompareCommand(client *pokeclient.Client, trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "compare",
+		Summary: "Compare Pokemon side by side",
+		Help: "Compares the height, weight, base experience, base stats, types and abilities of two or more Pokemon " +
+			"in a table. The Pokemon in your Pokedex are compared as they are and the others are looked up in the PokeAPI. " +
+			"The differences from the first Pokemon are shown in brackets. The type matchups show how effective the best " +
+			"type of each Pokemon is against the types of the others.",
+		Args: []Arg{
+			pokemonArg,
+			pokemonArg,
+			{Name: "pokemon", Description: "name of the Pokemon", Optional: true, Variadic: true, Normalise: resourceName},
+		},
+		Run: compareFunc(client, trainer),
+	}
+}
+
+func compareFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		names := input.Args
+		compared := make([]pokeapi.Pokemon, len(names))
+
+		for ind, name := range slices.All(names) {
+			pokemon, err := lookupPokemon(ctx, client, trainer, name)
+			if err != nil {
+				return err
+			}
+
+			compared[ind] = pokemon
+		}
+
+		types, err := comparedTypes(ctx, client, compared)
+		if err != nil {
+			return err
+		}
+
+		var builder strings.Builder
+
+		tableWriter := tabwriter.NewWriter(&builder, 0, 8, 2, ' ', 0)
+
+		writeRow(tableWriter, "", names)
+		writeRow(tableWriter, "Height", numericCells(compared, func(p pokeapi.Pokemon) int { return p.Height }))
+		writeRow(tableWriter, "Weight", numericCells(compared, func(p pokeapi.Pokemon) int { return p.Weight }))
+		writeRow(tableWriter, "Base experience", numericCells(compared, func(p pokeapi.Pokemon) int { return p.BaseExperience }))
+
+		for _, stat := range slices.All(statNames(compared)) {
+			writeRow(tableWriter, stat, numericCells(compared, func(p pokeapi.Pokemon) int { return baseStat(p, stat) }))
+		}
+
+		writeRow(tableWriter, "Base stat total", numericCells(compared, baseStatTotal))
+		writeRow(tableWriter, "Types", textCells(compared, typeNames))
+		writeRow(tableWriter, "Abilities", textCells(compared, abilityNames))
+
+		tableWriter.Flush()
+
+		builder.WriteString("\nType matchups (attacker against defender):\n")
+
+		matchupWriter := tabwriter.NewWriter(&builder, 0, 8, 2, ' ', 0)
+
+		writeRow(matchupWriter, "", names)
+
+		for attacker := range compared {
+			cells := make([]string, len(compared))
+
+			for defender := range compared {
+				if attacker == defender {
+					cells[defender] = "-"
+
+					continue
+				}
+
+				attackingType, multiplier := bestMatchup(compared[attacker], compared[defender], types)
+				cells[defender] = formatMultiplier(multiplier) + " (" + attackingType + ")"
+			}
+
+			writeRow(matchupWriter, names[attacker], cells)
+		}
+
+		matchupWriter.Flush()
+
+		builder.WriteString("\n")
+
+		for first := range compared {
+			for second := first + 1; second < len(compared); second++ {
+				builder.WriteString(advantage(names[first], names[second], compared[first], compared[second], types) + "\n")
+			}
+		}
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+// lookupPokemon returns the Pokemon from the trainer's Pokedex or from the PokeAPI
+// if the trainer hasn't caught it.
+func lookupPokemon(ctx context.Context, client *pokeclient.Client, trainer *poketrainer.Trainer, name string) (pokeapi.Pokemon, error) {
+	if pokemon, ok := trainer.GetPokemonFromPokedex(name); ok {
+		return pokemon, nil
+	}
+
+	pokemon, err := client.GetPokemon(ctx, name)
+	if err != nil {
+		return pokeapi.Pokemon{}, describeRequestError(
+			err,
+			"Pokemon",
+			name,
+			"unable to get the information on "+name,
+			resourceSuggester(ctx, client, pokeclient.PokemonResource),
+		)
+	}
+
+	return pokemon, nil
+}
+
+// comparedTypes returns the details of every type of the compared Pokemon.
+func comparedTypes(ctx context.Context, client *pokeclient.Client, compared []pokeapi.Pokemon) (map[string]pokeapi.Type, error) {
+	types := make(map[string]pokeapi.Type)
+
+	for _, pokemon := range slices.All(compared) {
+		for _, pType := range slices.All(pokemon.Types) {
+			if _, ok := types[pType.Type.Name]; ok {
+				continue
+			}
+
+			details, err := client.GetType(ctx, pType.Type.Name)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get the %s type: %w", pType.Type.Name, err)
+			}
+
+			types[pType.Type.Name] = details
+		}
+	}
+
+	return types, nil
+}
+
+func writeRow(writer io.Writer, label string, cells []string) {
+	fmt.Fprintln(writer, label+"\t"+strings.Join(cells, "\t"))
+}
+
+// numericCells returns the values of the Pokemon with the differences
+// from the first Pokemon after the first cell.
+func numericCells(compared []pokeapi.Pokemon, value func(pokeapi.Pokemon) int) []string {
+	cells := make([]string, len(compared))
+	first := value(compared[0])
+
+	for ind, pokemon := range slices.All(compared) {
+		cells[ind] = strconv.Itoa(value(pokemon))
+
+		if ind > 0 {
+			cells[ind] += " (" + formatDelta(value(pokemon)-first) + ")"
+		}
+	}
+
+	return cells
+}
+
+func textCells(compared []pokeapi.Pokemon, values func(pokeapi.Pokemon) []string) []string {
+	cells := make([]string, len(compared))
+
+	for ind, pokemon := range slices.All(compared) {
+		cells[ind] = strings.Join(values(pokemon), ", ")
+	}
+
+	return cells
+}
+
+func formatDelta(delta int) string {
+	switch {
+	case delta > 0:
+		return "+" + strconv.Itoa(delta)
+	case delta < 0:
+		return strconv.Itoa(delta)
+	default:
+		return "±0"
+	}
+}
+
+// statNames returns the names of the stats of all the compared Pokemon
+// in the order that they appear.
+func statNames(compared []pokeapi.Pokemon) []string {
+	var names []string
+
+	for _, pokemon := range slices.All(compared) {
+		for _, stat := range slices.All(pokemon.Stats) {
+			if !slices.Contains(names, stat.Stat.Name) {
+				names = append(names, stat.Stat.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+func baseStat(pokemon pokeapi.Pokemon, name string) int {
+	for _, stat := range slices.All(pokemon.Stats) {
+		if stat.Stat.Name == name {
+			return stat.BaseStat
+		}
+	}
+
+	return 0
+}
+
+func baseStatTotal(pokemon pokeapi.Pokemon) int {
+	total := 0
+
+	for _, stat := range slices.All(pokemon.Stats) {
+		total += stat.BaseStat
+	}
+
+	return total
+}
+
+func typeNames(pokemon pokeapi.Pokemon) []string {
+	names := make([]string, len(pokemon.Types))
+
+	for ind, pType := range slices.All(pokemon.Types) {
+		names[ind] = pType.Type.Name
+	}
+
+	return names
+}
+
+func abilityNames(pokemon pokeapi.Pokemon) []string {
+	names := make([]string, len(pokemon.Abilities))
+
+	for ind, ability := range slices.All(pokemon.Abilities) {
+		names[ind] = ability.Ability.Name
+
+		if ability.IsHidden {
+			names[ind] += " (hidden)"
+		}
+	}
+
+	return names
+}
+
+// effectiveness returns the damage multiplier of a move of the attacking
+// type against a Pokemon with the defending types.
+func effectiveness(attacking pokeapi.Type, defending []string) float64 {
+	multiplier := 1.0
+	relations := attacking.DamageRelations
+
+	for _, defendingType := range slices.All(defending) {
+		switch {
+		case hasResource(relations.NoDamageTo, defendingType):
+			multiplier = 0
+		case hasResource(relations.DoubleDamageTo, defendingType):
+			multiplier *= 2
+		case hasResource(relations.HalfDamageTo, defendingType):
+			multiplier *= 0.5
+		}
+	}
+
+	return multiplier
+}
+
+// bestMatchup returns the attacker's type that is the most effective
+// against the defender and its damage multiplier.
+func bestMatchup(attacker, defender pokeapi.Pokemon, types map[string]pokeapi.Type) (string, float64) {
+	defending := typeNames(defender)
+	bestType, best := "", -1.0
+
+	for _, attackingType := range slices.All(typeNames(attacker)) {
+		if multiplier := effectiveness(types[attackingType], defending); multiplier > best {
+			bestType, best = attackingType, multiplier
+		}
+	}
+
+	if best < 0 {
+		return "no type", 1
+	}
+
+	return bestType, best
+}
+
+// advantage describes which of the two Pokemon has the better type matchup against the other.
+func advantage(firstName, secondName string, first, second pokeapi.Pokemon, types map[string]pokeapi.Type) string {
+	_, firstMultiplier := bestMatchup(first, second, types)
+	_, secondMultiplier := bestMatchup(second, first, types)
+
+	switch {
+	case firstMultiplier > secondMultiplier:
+		return fmt.Sprintf(
+			"%s has the type advantage over %s (%s against %s).",
+			firstName,
+			secondName,
+			formatMultiplier(firstMultiplier),
+			formatMultiplier(secondMultiplier),
+		)
+	case secondMultiplier > firstMultiplier:
+		return fmt.Sprintf(
+			"%s has the type advantage over %s (%s against %s).",
+			secondName,
+			firstName,
+			formatMultiplier(secondMultiplier),
+			formatMultiplier(firstMultiplier),
+		)
+	default:
+		return fmt.Sprintf(
+			"Neither %s nor %s has a type advantage (%s each).",
+			firstName,
+			secondName,
+			formatMultiplier(firstMultiplier),
+		)
+	}
+}
+
+func formatMultiplier(multiplier float64) string {
+	return strconv.FormatFloat(multiplier, 'g', -1, 64) + "x"
+}
+
+func hasResource(resources []pokeapi.NamedAPIResource, name string) bool {
+	return slices.ContainsFunc(resources, func(resource pokeapi.NamedAPIResource) bool {
+		return resource.Name == name
+	})
+}
@@ -0,0 +1,81 @@
+package commands_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/commands"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func TestCompare(t *testing.T) {
+	server := pokeapitest.NewServer(t)
+	server.HandleJSON(
+		"/api/v2/pokemon/pikachu/",
+		`{
+			"name": "pikachu", "height": 4, "weight": 60, "base_experience": 112,
+			"stats": [{"stat": {"name": "hp"}, "base_stat": 35}, {"stat": {"name": "speed"}, "base_stat": 90}],
+			"types": [{"slot": 1, "type": {"name": "electric"}}],
+			"abilities": [{"is_hidden": false, "ability": {"name": "static"}}, {"is_hidden": true, "ability": {"name": "lightning-rod"}}]
+		}`,
+	)
+	server.HandleJSON(
+		"/api/v2/pokemon/gyarados/",
+		`{
+			"name": "gyarados", "height": 65, "weight": 2350, "base_experience": 189,
+			"stats": [{"stat": {"name": "hp"}, "base_stat": 95}, {"stat": {"name": "speed"}, "base_stat": 81}],
+			"types": [{"slot": 1, "type": {"name": "water"}}, {"slot": 2, "type": {"name": "flying"}}],
+			"abilities": [{"is_hidden": false, "ability": {"name": "intimidate"}}]
+		}`,
+	)
+	server.HandleJSON(
+		"/api/v2/type/electric/",
+		`{"name": "electric", "damage_relations": {"double_damage_to": [{"name": "water"}, {"name": "flying"}]}}`,
+	)
+	server.HandleJSON(
+		"/api/v2/type/water/",
+		`{"name": "water", "damage_relations": {"half_damage_to": [{"name": "water"}]}}`,
+	)
+	server.HandleJSON(
+		"/api/v2/type/flying/",
+		`{"name": "flying", "damage_relations": {"half_damage_to": [{"name": "electric"}]}}`,
+	)
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	registry := commands.NewRegistry()
+	if err := registry.Register(commands.CompareCommand(client, poketrainer.NewTrainer())); err != nil {
+		t.Fatalf("Unable to register the compare command: %v", err)
+	}
+
+	var output strings.Builder
+
+	if err := registry.Run(context.Background(), &output, []string{"compare", "pikachu", "gyarados"}); err != nil {
+		t.Fatalf("Unable to compare pikachu and gyarados: %v", err)
+	}
+
+	got := output.String()
+
+	wantLines := []string{
+		"Height           4                               65 (+61)",
+		"speed            90                              81 (-9)",
+		"Base stat total  125                             176 (+51)",
+		"Types            electric                        water, flying",
+		"Abilities        static, lightning-rod (hidden)  intimidate",
+		"pikachu   -           4x (electric)",
+		"gyarados  1x (water)  -",
+		"pikachu has the type advantage over gyarados (4x against 1x).",
+	}
+
+	for _, want := range slices.All(wantLines) {
+		if !strings.Contains(got, want) {
+			t.Errorf("The comparison does not contain %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func DaycareCommand(client *pokeclient.Client, trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "daycare",
+		Summary: "Leave Pokemon at the daycare to produce eggs",
+		Help: "Leave two compatible Pokemon at the daycare and they will produce an egg. Every action at the " +
+			"prompt counts as a step towards the next egg and towards hatching the eggs that you are carrying.",
+		Subcommands: []*Command{
+			{
+				Name:    "deposit",
+				Summary: "Leave a Pokemon from your Pokedex at the daycare",
+				Args:    []Arg{pokemonArg},
+				Run:     daycareDepositFunc(client, trainer),
+			},
+			{
+				Name:    "withdraw",
+				Summary: "Collect a Pokemon from the daycare",
+				Args:    []Arg{pokemonArg},
+				Run:     daycareWithdrawFunc(trainer),
+			},
+			{
+				Name:    "status",
+				Summary: "Display the Pokemon at the daycare and whether they can produce an egg",
+				Run:     daycareStatusFunc(trainer),
+			},
+		},
+	}
+}
+
+func EggsCommand(trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "eggs",
+		Summary: "List the eggs you are carrying",
+		Help:    "Lists the eggs that you are carrying and the number of steps until each one hatches.",
+		Run:     eggsFunc(trainer),
+	}
+}
+
+func eggsFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		eggs := trainer.Eggs()
+
+		if len(eggs) == 0 {
+			fmt.Fprintln(out, "You have no eggs.")
+
+			return nil
+		}
+
+		fmt.Fprintln(out, "Your eggs:")
+
+		for _, egg := range slices.All(eggs) {
+			fmt.Fprintf(out, "  - Egg #%d (%s): hatches in %d steps\n", egg.ID, egg.Species, egg.StepsRemaining)
+		}
+
+		return nil
+	}
+}
+
+// DaycareStep takes a step for the trainer, hatching any eggs that are ready and
+// collecting a new egg from the daycare when a compatible pair has produced one.
+func DaycareStep(ctx context.Context, out io.Writer, client *pokeclient.Client, trainer *poketrainer.Trainer) error {
+	hatched, eggDue, err := trainer.DaycareStep()
+	if err != nil {
+		return fmt.Errorf("unable to take a step: %w", err)
+	}
+
+	for _, name := range slices.All(hatched) {
+		fmt.Fprintf(out, "Oh? Your egg hatched into %s!\n", name)
+
+		if individual, ok := trainer.Individual(name); ok {
+			fmt.Fprintln(out, formatIndividual(individual))
+		}
+	}
+
+	if !eggDue {
+		return nil
+	}
+
+	if err := layEgg(ctx, client, trainer); err != nil {
+		return fmt.Errorf("unable to collect the egg from the daycare: %w", err)
+	}
+
+	fmt.Fprintln(out, "The daycare has an egg for you! Use the eggs command to see your eggs.")
+
+	return nil
+}
+
+func daycareDepositFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		pokemonName := input.Arg(0)
+
+		pokemon, ok := trainer.GetPokemonFromPokedex(pokemonName)
+		if !ok {
+			return notCaughtError(trainer, pokemonName)
+		}
+
+		species, err := client.GetPokemonSpecies(ctx, pokemon.Species.Name)
+		if err != nil {
+			return describeRequestError(
+				err,
+				"Pokemon species",
+				pokemon.Species.Name,
+				"unable to get the species information for "+pokemonName,
+				nil,
+			)
+		}
+
+		eggGroups := make([]string, len(species.EggGroups))
+
+		for ind, group := range slices.All(species.EggGroups) {
+			eggGroups[ind] = group.Name
+		}
+
+		entry := poketrainer.DaycarePokemon{
+			Name:       pokemonName,
+			Pokemon:    pokemon,
+			Individual: poketrainer.NewIndividual(pokemon, species.GenderRate),
+			Species:    species.Name,
+			EggGroups:  eggGroups,
+		}
+
+		if err := trainer.DepositInDaycare(entry); err != nil {
+			return fmt.Errorf("unable to deposit %s: %w", pokemonName, err)
+		}
+
+		fmt.Fprintf(out, "%s was left at the daycare.\n", pokemonName)
+
+		return nil
+	}
+}
+
+func daycareWithdrawFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		pokemonName := input.Arg(0)
+
+		if err := trainer.WithdrawFromDaycare(pokemonName); err != nil {
+			return fmt.Errorf("unable to withdraw %s: %w", pokemonName, err)
+		}
+
+		fmt.Fprintf(out, "%s was collected from the daycare.\n", pokemonName)
+
+		return nil
+	}
+}
+
+func daycareStatusFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		pair := trainer.Daycare()
+
+		if len(pair) == 0 {
+			fmt.Fprintln(out, "There are no Pokemon at the daycare.")
+
+			return nil
+		}
+
+		var builder strings.Builder
+
+		builder.WriteString("At the daycare:\n")
+
+		for _, entry := range slices.All(pair) {
+			fmt.Fprintf(&builder, "  - %s (%s, egg groups: %s)\n", entry.Name, entry.Individual.Gender, strings.Join(entry.EggGroups, ", "))
+		}
+
+		if len(pair) == 2 {
+			if err := poketrainer.CanBreed(pair[0], pair[1]); err != nil {
+				fmt.Fprintf(&builder, "The two prefer to play with other Pokemon (%v).\n", err)
+			} else {
+				fmt.Fprintf(&builder, "The two seem to get along. The next egg is due in %d steps.\n", trainer.StepsUntilNextEgg())
+			}
+		}
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+func layEgg(ctx context.Context, client *pokeclient.Client, trainer *poketrainer.Trainer) error {
+	pair := trainer.Daycare()
+	if len(pair) != 2 {
+		return errors.New("there is no pair of Pokemon at the daycare")
+	}
+
+	parent := poketrainer.EggParent(pair[0], pair[1])
+
+	species, err := client.GetPokemonSpecies(ctx, parent.Species)
+	if err != nil {
+		return fmt.Errorf("unable to get the species information for %s: %w", parent.Species, err)
+	}
+
+	// The egg hatches into the first species of the parent's evolution line.
+	for species.EvolvesFromSpecies != nil {
+		species, err = client.GetPokemonSpecies(ctx, species.EvolvesFromSpecies.Name)
+		if err != nil {
+			return fmt.Errorf("unable to get the species information for the egg: %w", err)
+		}
+	}
+
+	babyName := species.Name
+
+	for _, variety := range slices.All(species.Varieties) {
+		if variety.IsDefault {
+			babyName = variety.Pokemon.Name
+
+			break
+		}
+	}
+
+	baby, err := client.GetPokemon(ctx, babyName)
+	if err != nil {
+		return fmt.Errorf("unable to get the information on %s: %w", babyName, err)
+	}
+
+	egg := poketrainer.Egg{
+		ID:             0,
+		Species:        species.Name,
+		Pokemon:        baby,
+		Individual:     poketrainer.InheritIndividual(pair[0], pair[1], baby, species.GenderRate),
+		StepsRemaining: max(species.HatchCounter, 1),
+	}
+
+	if err := trainer.LayEgg(egg); err != nil {
+		return fmt.Errorf("unable to add the egg: %w", err)
+	}
+
+	return nil
+}
+
+func formatIndividual(individual poketrainer.Individual) string {
+	info := "Gender: " + string(individual.Gender) + "\nIVs:"
+
+	for _, stat := range slices.Sorted(maps.Keys(individual.IVs)) {
+		info += fmt.Sprintf("\n  - %s: %d", stat, individual.IVs[stat])
+	}
+
+	info += "\nMoves:"
+
+	for _, move := range slices.All(individual.Moves) {
+		info += "\n  - " + move
+	}
+
+	return info
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/fuzzy"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+const maxSuggestions = 3
+
+// suggestFunc returns the names that closely match a misspelled name.
+type suggestFunc func(name string) []string
+
+// describeRequestError replaces errors from the client with friendly messages
+// that can be shown to the user. Any other error is wrapped with the message.
+func describeRequestError(err error, kind, name, message string, suggest suggestFunc) error {
+	var (
+		notFound    *pokeclient.NotFoundError
+		rateLimited *pokeclient.RateLimitedError
+		serverErr   *pokeclient.ServerError
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		return notFoundError(kind, name, suggest)
+	case errors.As(err, &rateLimited):
+		return errors.New("the PokeAPI is receiving too many requests right now, please try again later")
+	case errors.As(err, &serverErr):
+		return fmt.Errorf("the PokeAPI is having problems right now (%s), please try again later", serverErr.Status)
+	default:
+		return fmt.Errorf("%s: %w", message, err)
+	}
+}
+
+// notFoundError returns an error for a name that could not be found along
+// with suggestions for what the user might have meant.
+func notFoundError(kind, name string, suggest suggestFunc) error {
+	return fmt.Errorf("no %s called '%s'%s", kind, name, suggestionHint(suggest, name))
+}
+
+// notCaughtError returns an error for a Pokemon that is not in the trainer's
+// Pokedex along with suggestions for what the user might have meant.
+func notCaughtError(trainer *poketrainer.Trainer, name string) error {
+	return fmt.Errorf("you haven't caught a %s%s", name, suggestionHint(pokedexSuggester(trainer), name))
+}
+
+// suggestionHint returns a hint listing the closest matches for the name,
+// or an empty string if there are none.
+func suggestionHint(suggest suggestFunc, name string) string {
+	if suggest == nil {
+		return ""
+	}
+
+	suggestions := suggest(name)
+
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	return " (did you mean " + joinChoices(suggestions) + "?)"
+}
+
+// resourceSuggester suggests names from the index of every resource in the endpoint.
+func resourceSuggester[T any](ctx context.Context, client *pokeclient.Client, resource pokeclient.Resource[T]) suggestFunc {
+	return func(name string) []string {
+		names, err := pokeclient.Names(ctx, client, resource)
+		if err != nil {
+			return nil
+		}
+
+		return fuzzy.Suggest(name, names, maxSuggestions)
+	}
+}
+
+// pokedexSuggester suggests names from the Pokemon in the trainer's Pokedex.
+func pokedexSuggester(trainer *poketrainer.Trainer) suggestFunc {
+	return func(name string) []string {
+		return fuzzy.Suggest(name, trainer.PokedexNames(), maxSuggestions)
+	}
+}
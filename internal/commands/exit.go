@@ -1,9 +1,25 @@
 package commands
 
-import "os"
+import (
+	"context"
+	"errors"
+	"io"
+)
 
-func ExitProgram(_ []string) error {
-	os.Exit(0)
+// ErrExit is returned by the exit command to ask the caller to stop running
+// commands and exit the program.
+var ErrExit = errors.New("exit the program")
 
-	return nil
+func ExitCommand() *Command {
+	return &Command{
+		Name:    "exit",
+		Aliases: []string{"quit"},
+		Summary: "Exit the Pokedex",
+		Help:    "Exits the Pokedex. Your progress is saved as you play.",
+		Run:     exitProgram,
+	}
+}
+
+func exitProgram(_ context.Context, _ io.Writer, _ Input) error {
+	return ErrExit
 }
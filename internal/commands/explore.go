@@ -1,31 +1,45 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"slices"
 
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
 )
 
-func ExploreFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
-	return func(_ []string) error {
+func ExploreCommand(client *pokeclient.Client, trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "explore",
+		Summary: "List all the Pokemon in the location area you are visiting",
+		Help:    "Lists all the Pokemon that can be found in the location area that you are visiting.",
+		Run:     exploreFunc(client, trainer),
+	}
+}
+
+func exploreFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, _ Input) error {
 		locationAreaName := trainer.CurrentLocationAreaName()
 
-		fmt.Printf("Exploring %s...\n", locationAreaName)
+		fmt.Fprintf(out, "Exploring %s...\n", locationAreaName)
 
-		locationArea, err := client.GetLocationArea(locationAreaName)
+		locationArea, err := client.GetLocationArea(ctx, locationAreaName)
 		if err != nil {
-			return fmt.Errorf(
-				"unable to get the location area: %w",
+			return describeRequestError(
 				err,
+				"location area",
+				locationAreaName,
+				"unable to get the location area",
+				nil,
 			)
 		}
 
-		fmt.Println("Found Pokemon:")
+		fmt.Fprintln(out, "Found Pokemon:")
 
 		for _, encounter := range slices.All(locationArea.PokemonEncounters) {
-			fmt.Printf("- %s\n", encounter.Pokemon.Name)
+			fmt.Fprintf(out, "- %s\n", encounter.Pokemon.Name)
 		}
 
 		return nil
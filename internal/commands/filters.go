@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// FilterFunc reads the output of the previous stage of a pipeline and
+// writes the filtered output.
+type FilterFunc func(in io.Reader, out io.Writer, input Input) error
+
+func GrepCommand() *Command {
+	return &Command{
+		Name:    "grep",
+		Summary: "Display the lines of the piped output that match a pattern",
+		Help:    "Displays the lines of the output piped into it that match the regular expression, e.g. pokedex | grep fire.",
+		Args: []Arg{
+			{Name: "pattern", Description: "pattern to match"},
+		},
+		Flags: []Flag{
+			{Name: "ignore-case", Description: "Ignore the case of the letters when matching", Kind: FlagBool},
+			{Name: "invert", Description: "Display the lines that do not match the pattern", Kind: FlagBool},
+		},
+		Filter: grepFilter,
+	}
+}
+
+func CountCommand() *Command {
+	return &Command{
+		Name:    "count",
+		Summary: "Count the items in the piped output",
+		Help:    "Counts the list items in the output piped into it, e.g. explore | count. If the output is not a list then every line that is not blank is counted.",
+		Filter:  countFilter,
+	}
+}
+
+func grepFilter(in io.Reader, out io.Writer, input Input) error {
+	expr := input.Arg(0)
+	if input.Bool("ignore-case") {
+		expr = "(?i)" + expr
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", input.Arg(0), err)
+	}
+
+	scanner := bufio.NewScanner(in)
+
+	for scanner.Scan() {
+		if pattern.MatchString(scanner.Text()) != input.Bool("invert") {
+			fmt.Fprintln(out, scanner.Text())
+		}
+	}
+
+	return scanner.Err()
+}
+
+// countFilter counts the lines that are list items, i.e. the lines that start with
+// a dash, so that the headings above the lists are not included. Every line that
+// is not blank is counted if there are no list items.
+func countFilter(in io.Reader, out io.Writer, _ Input) error {
+	var items, lines int
+
+	scanner := bufio.NewScanner(in)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		lines++
+
+		if strings.HasPrefix(line, "- ") {
+			items++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if items == 0 {
+		items = lines
+	}
+
+	fmt.Fprintln(out, items)
+
+	return nil
+}
@@ -1,40 +1,126 @@
 package commands
 
 import (
+	"context"
 	"fmt"
-	"maps"
-	"os"
+	"io"
 	"slices"
 	"strings"
 	"text/tabwriter"
 )
 
-func HelpFunc(summaries map[string]string) CommandFunc {
-	return func(_ []string) error {
-		keys := []string{}
+func HelpCommand(registry *Registry) *Command {
+	return &Command{
+		Name:    "help",
+		Aliases: []string{"?"},
+		Summary: "Display the help message",
+		Help:    "Lists all the commands, or displays the detailed usage of a command and its subcommands.",
+		Args: []Arg{
+			{Name: "command", Description: "command", Optional: true, Variadic: true},
+		},
+		Run: helpFunc(registry),
+	}
+}
+
+func helpFunc(registry *Registry) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		if len(input.Args) == 0 {
+			fmt.Fprint(out, commandList(registry))
+
+			return nil
+		}
+
+		cmd, ok := registry.Lookup(input.Arg(0))
+		if !ok {
+			return fmt.Errorf("%w '%s'", ErrUnknownCommand, input.Arg(0))
+		}
+
+		usagePrefix := ""
+
+		for _, name := range slices.All(input.Args[1:]) {
+			sub := cmd.subcommand(name)
+			if sub == nil {
+				return fmt.Errorf("the %s command has no %q action", cmd.Name, name)
+			}
 
-		for key := range maps.All(summaries) {
-			keys = append(keys, key)
+			usagePrefix += cmd.Name + " "
+			cmd = sub
 		}
 
-		slices.Sort(keys)
+		fmt.Fprint(out, commandHelp(cmd, usagePrefix))
+
+		return nil
+	}
+}
+
+func commandList(registry *Registry) string {
+	var builder strings.Builder
+
+	builder.WriteString("\nCommands:\n")
+
+	tableWriter := tabwriter.NewWriter(&builder, 0, 8, 0, '\t', 0)
+
+	for _, cmd := range slices.All(registry.Commands()) {
+		fmt.Fprintf(tableWriter, "\n%s\t%s", cmd.Name, cmd.Summary)
+	}
+
+	tableWriter.Flush()
+
+	builder.WriteString("\n\nUse 'help <command>' to see the usage of a command.\n\n")
+
+	return builder.String()
+}
+
+// commandHelp returns the detailed usage of the command. The usage prefix
+// is the names of the parent commands of a subcommand.
+func commandHelp(cmd *Command, usagePrefix string) string {
+	var builder strings.Builder
+
+	builder.WriteString("\nUsage: " + usagePrefix + cmd.Usage() + "\n\n")
+	builder.WriteString(cmd.Summary + ".\n")
+
+	if cmd.Help != "" {
+		builder.WriteString(cmd.Help + "\n")
+	}
+
+	if len(cmd.Aliases) > 0 {
+		builder.WriteString("\nAliases: " + strings.Join(cmd.Aliases, ", ") + "\n")
+	}
 
-		var builder strings.Builder
+	tableWriter := tabwriter.NewWriter(&builder, 0, 8, 2, ' ', 0)
 
-		builder.WriteString("\nCommands:\n")
+	if len(cmd.Args) > 0 {
+		fmt.Fprint(tableWriter, "\nArguments:\n")
 
-		tableWriter := tabwriter.NewWriter(&builder, 0, 8, 0, '\t', 0)
+		for _, arg := range slices.All(cmd.Args) {
+			description := "The " + arg.Description
+			if arg.Optional {
+				description += " (optional)"
+			}
 
-		for _, key := range slices.All(keys) {
-			fmt.Fprintf(tableWriter, "\n%s\t%s", key, summaries[key])
+			fmt.Fprintf(tableWriter, "  <%s>\t%s\n", arg.Name, description)
 		}
+	}
 
-		tableWriter.Flush()
+	if len(cmd.Flags) > 0 {
+		fmt.Fprint(tableWriter, "\nFlags:\n")
 
-		builder.WriteString("\n\n")
+		for _, flag := range slices.All(cmd.Flags) {
+			fmt.Fprintf(tableWriter, "  --%s\t%s\n", flag.Name, flag.Description)
+		}
+	}
 
-		fmt.Fprint(os.Stdout, builder.String())
+	if len(cmd.Subcommands) > 0 {
+		fmt.Fprint(tableWriter, "\nActions:\n")
 
-		return nil
+		for _, sub := range slices.All(cmd.Subcommands) {
+			fmt.Fprintf(tableWriter, "  %s %s\t%s\n", usagePrefix+cmd.Name, sub.Usage(), sub.Summary)
+		}
 	}
+
+	tableWriter.Flush()
+
+	builder.WriteString("\n")
+
+	return builder.String()
 }
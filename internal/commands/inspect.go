@@ -1,31 +1,63 @@
 package commands
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"io"
 	"slices"
 
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/render"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/sprite"
 )
 
-func InspectFunc(trainer *poketrainer.Trainer) CommandFunc {
-	return func(args []string) error {
-		if args == nil {
-			return errors.New("the name of the Pokemon has not been specified")
-		}
+const (
+	spriteMaxWidth  = 40
+	spriteMaxHeight = 20
+)
 
-		if len(args) != 1 {
-			return fmt.Errorf(
-				"unexpected number of Pokemon names: want 1; got %d",
-				len(args),
-			)
-		}
+func InspectCommand(client *pokeclient.Client, trainer *poketrainer.Trainer, renderer *render.Renderer) *Command {
+	return &Command{
+		Name:    "inspect",
+		Summary: "Inspect a Pokemon from your Pokedex",
+		Help:    "Displays the height, weight, base stats, types, abilities and wild held items of a Pokemon in your Pokedex along with its held item, gender, IVs and moves if they are known.",
+		Args:    []Arg{pokemonArg},
+		Flags: []Flag{
+			{Name: "sprite", Description: "Display the Pokemon's sprite", Kind: FlagBool},
+			{Name: "shiny", Description: "Display the shiny sprite instead of the default one", Kind: FlagBool},
+			{Name: "back", Description: "Display the sprite of the Pokemon's back", Kind: FlagBool},
+		},
+		Run: inspectFunc(client, trainer, renderer),
+	}
+}
 
-		pokemonName := args[0]
+func inspectFunc(client *pokeclient.Client, trainer *poketrainer.Trainer, renderer *render.Renderer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		pokemonName := input.Arg(0)
+		painter := renderer.Painter(out)
 
 		pokemon, ok := trainer.GetPokemonFromPokedex(pokemonName)
 		if !ok {
-			return fmt.Errorf("you have not caught %s", pokemonName)
+			return notCaughtError(trainer, pokemonName)
+		}
+
+		if input.Bool("sprite") || input.Bool("shiny") || input.Bool("back") {
+			url := spriteURL(pokemon.Sprites, input.Bool("shiny"), input.Bool("back"))
+
+			// The sprite is a nice to have so the details are still
+			// displayed if it cannot be downloaded.
+			img, err := client.GetSprite(ctx, url)
+			if err != nil {
+				if ctx.Err() != nil {
+					return fmt.Errorf("unable to get the sprite: %w", err)
+				}
+
+				fmt.Fprintf(out, "(The sprite is not available: %v.)\n", err)
+			} else {
+				fmt.Fprint(out, sprite.Render(img, spriteMaxWidth, spriteMaxHeight, painter.Mode()))
+			}
 		}
 
 		info := fmt.Sprintf(
@@ -35,22 +67,71 @@ func InspectFunc(trainer *poketrainer.Trainer) CommandFunc {
 			pokemon.Weight,
 		)
 
+		// The names are padded by hand because the colours
+		// in the bars would throw off a tabwriter.
+		nameWidth := 0
+
+		for _, stat := range slices.All(pokemon.Stats) {
+			nameWidth = max(nameWidth, len(stat.Stat.Name)+1)
+		}
+
 		for _, stat := range slices.All(pokemon.Stats) {
 			info += fmt.Sprintf(
-				"\n  - %s: %d",
-				stat.Stat.Name,
+				"\n  - %-*s %3d %s",
+				nameWidth,
+				stat.Stat.Name+":",
 				stat.BaseStat,
+				painter.StatBar(stat.BaseStat),
 			)
 		}
 
 		info += "\nTypes:"
 
 		for _, pType := range slices.All(pokemon.Types) {
-			info += "\n  - " + pType.Type.Name
+			info += "\n  - " + painter.Type(pType.Type.Name)
 		}
 
-		fmt.Println(info)
+		if len(pokemon.Abilities) > 0 {
+			info += "\nAbilities:"
+
+			for _, ability := range slices.All(abilityNames(pokemon)) {
+				info += "\n  - " + ability
+			}
+		}
+
+		if len(pokemon.HeldItems) > 0 {
+			info += "\nHeld items in the wild:"
+
+			for _, heldItem := range slices.All(pokemon.HeldItems) {
+				info += "\n  - " + heldItem.Item.Name + ": " + formatRarities(heldItemRarities(heldItem))
+			}
+		}
+
+		if heldItem, ok := trainer.HeldItem(pokemonName); ok {
+			info += "\nHolding: " + heldItem
+		}
+
+		if individual, ok := trainer.Individual(pokemonName); ok {
+			info += "\n" + formatIndividual(individual)
+		}
+
+		fmt.Fprintln(out, info)
 
 		return nil
 	}
 }
+
+// spriteURL returns the URL of the Pokemon's default or shiny sprite
+// facing either the front or the back.
+func spriteURL(sprites pokeapi.PokemonSprites, shiny, back bool) string {
+	switch {
+	case back && shiny:
+		return sprites.BackShiny
+	case back:
+		return sprites.BackDefault
+	case shiny:
+		return sprites.FrontShiny
+	default:
+		return sprites.FrontDefault
+	}
+}
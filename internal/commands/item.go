@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func ItemCommand(client *pokeclient.Client) *Command {
+	return &Command{
+		Name:    "item",
+		Summary: "Display the details of an item and the wild Pokemon that hold it",
+		Help: "Displays the category, cost and effect of an item and lists the wild Pokemon that may be holding it " +
+			"along with the chance of them holding it in each version of the games.",
+		Args: []Arg{{Name: "item", Description: "name of the item", Normalise: resourceName}},
+		Run:  itemFunc(client),
+	}
+}
+
+func itemFunc(client *pokeclient.Client) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		itemName := input.Arg(0)
+
+		item, err := client.GetItem(ctx, itemName)
+		if err != nil {
+			return describeRequestError(
+				err,
+				"item",
+				itemName,
+				"unable to get the information on "+itemName,
+				resourceSuggester(ctx, client, pokeclient.ItemResource),
+			)
+		}
+
+		var builder strings.Builder
+
+		fmt.Fprintf(&builder, "Name: %s\nCategory: %s\n", item.Name, item.Category.Name)
+
+		if item.Cost > 0 {
+			fmt.Fprintf(&builder, "Cost: %d\n", item.Cost)
+		} else {
+			builder.WriteString("Cost: cannot be bought\n")
+		}
+
+		if item.FlingPower != nil {
+			fmt.Fprintf(&builder, "Fling power: %d\n", *item.FlingPower)
+		}
+
+		effect, shortEffect := itemEffect(item)
+		writeEffect(&builder, effect, shortEffect)
+
+		if len(item.HeldByPokemon) > 0 {
+			builder.WriteString("Held by wild Pokemon:\n")
+
+			for _, holder := range slices.All(item.HeldByPokemon) {
+				builder.WriteString("  - " + holder.Pokemon.Name + ": " + formatRarities(holderRarities(holder)) + "\n")
+			}
+		}
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+// itemEffect returns the effect and short effect of the item in English.
+// The items that do not have an effect entry are described by their latest
+// flavour text instead.
+func itemEffect(item pokeapi.Item) (string, string) {
+	if effect, shortEffect, ok := englishEffect(item.EffectEntries); ok {
+		return effect, shortEffect
+	}
+
+	for _, entry := range slices.Backward(item.FlavorTextEntries) {
+		if entry.Language.Name == language {
+			return "", flattenText(entry.Text)
+		}
+	}
+
+	return "", ""
+}
+
+// versionRarity is the chance of a wild Pokemon holding an item in a version of the games.
+type versionRarity struct {
+	version string
+	rarity  int
+}
+
+// formatRarities groups the versions by the chance of the Pokemon holding
+// the item, e.g. "50% in ruby, sapphire; 5% in emerald".
+func formatRarities(rarities []versionRarity) string {
+	versions := make(map[int][]string)
+
+	for _, details := range slices.All(rarities) {
+		versions[details.rarity] = append(versions[details.rarity], details.version)
+	}
+
+	groups := make([]string, 0, len(versions))
+
+	for _, rarity := range slices.SortedFunc(maps.Keys(versions), func(a, b int) int { return cmp.Compare(b, a) }) {
+		groups = append(groups, strconv.Itoa(rarity)+"% in "+strings.Join(versions[rarity], ", "))
+	}
+
+	return strings.Join(groups, "; ")
+}
+
+// holderRarities returns the chances of the wild Pokemon holding the item in each version.
+func holderRarities(holder pokeapi.ItemHolderPokemon) []versionRarity {
+	rarities := make([]versionRarity, len(holder.VersionDetails))
+
+	for ind, details := range slices.All(holder.VersionDetails) {
+		rarities[ind] = versionRarity{version: details.Version.Name, rarity: details.Rarity}
+	}
+
+	return rarities
+}
+
+// heldItemRarities returns the chances of the wild Pokemon holding the item in each version.
+func heldItemRarities(heldItem pokeapi.PokemonHeldItems) []versionRarity {
+	rarities := make([]versionRarity, len(heldItem.VersionDetails))
+
+	for ind, details := range slices.All(heldItem.VersionDetails) {
+		rarities[ind] = versionRarity{version: details.Version.Name, rarity: details.Rarity}
+	}
+
+	return rarities
+}
@@ -0,0 +1,103 @@
+package commands_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/commands"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func TestItemAndBerry(t *testing.T) {
+	server := pokeapitest.NewServer(t)
+	server.HandleJSON(
+		"/api/v2/item/oran-berry/",
+		`{
+			"name": "oran-berry",
+			"cost": 20,
+			"fling_power": 10,
+			"category": {"name": "medicine"},
+			"effect_entries": [{"effect": "Restores 10 HP.", "short_effect": "Restores 10 HP.", "language": {"name": "en"}}],
+			"held_by_pokemon": [
+				{
+					"pokemon": {"name": "wingull"},
+					"version_details": [
+						{"rarity": 5, "version": {"name": "emerald"}},
+						{"rarity": 50, "version": {"name": "ruby"}},
+						{"rarity": 50, "version": {"name": "sapphire"}}
+					]
+				}
+			]
+		}`,
+	)
+	server.HandleJSON(
+		"/api/v2/berry/oran/",
+		`{
+			"name": "oran",
+			"growth_time": 4,
+			"max_harvest": 5,
+			"natural_gift_power": 60,
+			"size": 35,
+			"smoothness": 20,
+			"soil_dryness": 15,
+			"firmness": {"name": "super-hard"},
+			"flavors": [{"potency": 10, "flavor": {"name": "spicy"}}, {"potency": 0, "flavor": {"name": "sweet"}}],
+			"item": {"name": "oran-berry"},
+			"natural_gift_type": {"name": "poison"}
+		}`,
+	)
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	registry := commands.NewRegistry()
+	if err := registry.Register(commands.ItemCommand(client), commands.BerryCommand(client)); err != nil {
+		t.Fatalf("Unable to register the item and berry commands: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "Item",
+			args: []string{"item", "Oran Berry"},
+			want: []string{
+				"Category: medicine\nCost: 20\nFling power: 10\n",
+				"Short effect: Restores 10 HP.\n",
+				"Held by wild Pokemon:\n  - wingull: 50% in ruby, sapphire; 5% in emerald\n",
+			},
+		},
+		{
+			name: "Berry",
+			args: []string{"berry", "oran-berry"},
+			want: []string{
+				"Name: oran\nItem: oran-berry\nFirmness: super-hard\n",
+				"Natural Gift: poison (power 60)\n",
+				"Flavours:\n  - spicy: 10\n",
+				"Short effect: Restores 10 HP.\n",
+			},
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			var output strings.Builder
+
+			if err := registry.Run(context.Background(), &output, testcase.args); err != nil {
+				t.Fatalf("Unable to run %v: %v", testcase.args, err)
+			}
+
+			for _, want := range slices.All(testcase.want) {
+				if !strings.Contains(output.String(), want) {
+					t.Errorf("The output does not contain %q:\n%s", want, output.String())
+				}
+			}
+		})
+	}
+}
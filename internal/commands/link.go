@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokelink"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrade"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+const (
+	defaultLinkAddress = ":7777"
+	linkTimeout        = 2 * time.Minute
+)
+
+// linkModeArg is the argument for the mode of a link session.
+var linkModeArg = Arg{
+	Name:        "mode",
+	Description: "link mode (trade or battle)",
+	Choices:     []string{string(pokelink.ModeTrade), string(pokelink.ModeBattle)},
+	Normalise:   strings.ToLower,
+}
+
+func HostCommand(trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "host",
+		Summary: "Host a trade or battle with a trainer on your network",
+		Help: "Waits for another trainer on your network to join a trade or battle. " +
+			"The host listens on " + defaultLinkAddress + " unless an address is specified.",
+		Args: []Arg{
+			linkModeArg,
+			pokemonArg,
+			{Name: "address", Description: "address to listen on", Optional: true},
+		},
+		Run: hostFunc(trainer),
+	}
+}
+
+func JoinCommand(trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "join",
+		Summary: "Join a trade or battle hosted by another trainer",
+		Help:    "Connects to a trainer who is hosting a trade or battle. Both trainers must choose the same mode.",
+		Args: []Arg{
+			{Name: "address", Description: "address of the host"},
+			linkModeArg,
+			pokemonArg,
+		},
+		Run: joinFunc(trainer),
+	}
+}
+
+func hostFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		mode, pokemonName := pokelink.Mode(input.Arg(0)), input.Arg(1)
+
+		address := defaultLinkAddress
+		if input.Arg(2) != "" {
+			address = input.Arg(2)
+		}
+
+		pokemon, err := linkPreflight(trainer, pokemonName)
+		if err != nil {
+			return err
+		}
+
+		listener, err := pokelink.Listen(address, linkTimeout)
+		if err != nil {
+			return fmt.Errorf("unable to host: %w", err)
+		}
+		defer listener.Close()
+
+		// Closing the listener stops it from waiting for a trainer to join.
+		stopListening := context.AfterFunc(ctx, func() { _ = listener.Close() })
+		defer stopListening()
+
+		fmt.Fprintf(out, "Waiting for a trainer to join on %s...\n", listener.Addr())
+
+		peer, err := listener.Accept(mode)
+		if err != nil {
+			return fmt.Errorf("unable to connect with the other trainer: %w", linkError(ctx, err))
+		}
+		defer peer.Close()
+
+		stopSession := context.AfterFunc(ctx, func() { _ = peer.Close() })
+		defer stopSession()
+
+		if err := runLinkSession(out, peer, trainer, mode, pokemonName, pokemon); err != nil {
+			return linkError(ctx, err)
+		}
+
+		return nil
+	}
+}
+
+func joinFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		address, mode, pokemonName := input.Arg(0), pokelink.Mode(input.Arg(1)), input.Arg(2)
+
+		pokemon, err := linkPreflight(trainer, pokemonName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Joining the trainer at %s...\n", address)
+
+		peer, err := pokelink.Join(ctx, address, mode, linkTimeout)
+		if err != nil {
+			return linkError(ctx, fmt.Errorf("unable to connect with the other trainer: %w", err))
+		}
+		defer peer.Close()
+
+		stopSession := context.AfterFunc(ctx, func() { _ = peer.Close() })
+		defer stopSession()
+
+		if err := runLinkSession(out, peer, trainer, mode, pokemonName, pokemon); err != nil {
+			return linkError(ctx, err)
+		}
+
+		return nil
+	}
+}
+
+// linkError returns the context's error in place of the error from a
+// connection that was closed because the command was cancelled.
+func linkError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
+func linkPreflight(trainer *poketrainer.Trainer, pokemonName string) (pokeapi.Pokemon, error) {
+	pokemon, ok := trainer.GetPokemonFromPokedex(pokemonName)
+	if !ok {
+		return pokeapi.Pokemon{}, notCaughtError(trainer, pokemonName)
+	}
+
+	return pokemon, nil
+}
+
+func runLinkSession(
+	out io.Writer,
+	peer *pokelink.Peer,
+	trainer *poketrainer.Trainer,
+	mode pokelink.Mode,
+	pokemonName string,
+	pokemon pokeapi.Pokemon,
+) error {
+	fmt.Fprintln(out, "Connected!")
+
+	if mode == pokelink.ModeBattle {
+		return linkBattle(out, peer, pokemonName, pokemon)
+	}
+
+	return linkTrade(out, peer, trainer, pokemonName, pokemon)
+}
+
+func linkTrade(
+	out io.Writer,
+	peer *pokelink.Peer,
+	trainer *poketrainer.Trainer,
+	pokemonName string,
+	pokemon pokeapi.Pokemon,
+) error {
+	canReceive := func(traded poketrade.TradedPokemon) error {
+		if _, ok := trainer.GetPokemonFromPokedex(traded.Name); ok && traded.Name != pokemonName {
+			return fmt.Errorf("the trainer already has a %s", traded.Name)
+		}
+
+		return nil
+	}
+
+	received, err := peer.Trade(tradedPokemon(trainer, pokemonName, pokemon), canReceive)
+	if err != nil {
+		if errors.Is(err, pokelink.ErrTradeRejected) {
+			return fmt.Errorf("the trade did not go through: %w", err)
+		}
+
+		return fmt.Errorf("unable to trade: %w", err)
+	}
+
+	if err := trainer.TradeAwayPokemon(pokemonName); err != nil {
+		return fmt.Errorf("unable to trade away %s: %w", pokemonName, err)
+	}
+
+	if err := trainer.ReceiveTradedPokemon(received.Name, received.Pokemon, received.Individual, received.HeldItem); err != nil {
+		return fmt.Errorf("unable to receive %s: %w", received.Name, err)
+	}
+
+	fmt.Fprintf(out, "You traded %s for %s!\n", pokemonName, received.Name)
+
+	return nil
+}
+
+func linkBattle(out io.Writer, peer *pokelink.Peer, pokemonName string, pokemon pokeapi.Pokemon) error {
+	result, err := peer.Battle(pokemonName, pokemon)
+	if err != nil {
+		return fmt.Errorf("unable to battle: %w", err)
+	}
+
+	for _, line := range slices.All(result.Log) {
+		fmt.Fprintln(out, line)
+	}
+
+	if result.Won {
+		fmt.Fprintf(out, "%s won the battle in %d turns!\n", pokemonName, result.Turns)
+	} else {
+		fmt.Fprintf(out, "%s lost the battle after %d turns.\n", pokemonName, result.Turns)
+	}
+
+	return nil
+}
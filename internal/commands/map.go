@@ -1,53 +1,94 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"slices"
 
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
 )
 
-func MapFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
-	return func(_ []string) error {
+func MapCommand(client *pokeclient.Client, trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "map",
+		Summary: "Display the next 20 locations in the Pokemon world",
+		Help:    "Displays the next page of 20 location areas in the Pokemon world. The details of the location areas are fetched in the background so that visiting them is instant.",
+		Run:     mapFunc(client, trainer),
+	}
+}
+
+func MapBCommand(client *pokeclient.Client, trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "mapb",
+		Summary: "Display the previous 20 locations in the Pokemon world",
+		Help:    "Displays the previous page of 20 location areas in the Pokemon world.",
+		Run:     mapBFunc(client, trainer),
+	}
+}
+
+func mapFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, _ Input) error {
 		url := trainer.NextLocationArea()
 		if url == nil {
 			url = new(string)
-			*url = pokeclient.LocationAreaPath
+			*url = client.ListURL(pokeclient.LocationAreaResource.Path)
 		}
 
-		return printResourceList(client, *url, trainer.UpdateLocationAreas)
+		return printLocationAreas(ctx, out, client, *url, trainer)
 	}
 }
 
-func MapBFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
-	return func(_ []string) error {
+func mapBFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, _ Input) error {
 		url := trainer.PreviousLocationArea()
 		if url == nil {
 			return fmt.Errorf("no previous locations available")
 		}
 
-		return printResourceList(client, *url, trainer.UpdateLocationAreas)
+		return printLocationAreas(ctx, out, client, *url, trainer)
 	}
 }
 
+// printLocationAreas prints the page of location areas and prefetches their
+// details in the background so that visiting or exploring them is instant.
+func printLocationAreas(ctx context.Context, out io.Writer, client *pokeclient.Client, url string, trainer *poketrainer.Trainer) error {
+	names, err := printResourceList(ctx, out, client, url, trainer.UpdateLocationAreas)
+	if err != nil {
+		return err
+	}
+
+	pokeclient.Prefetch(client, pokeclient.LocationAreaResource, names...)
+
+	return nil
+}
+
 func printResourceList(
+	ctx context.Context,
+	out io.Writer,
 	client *pokeclient.Client,
 	url string,
-	updateStateFunc func(previous *string, next *string),
-) error {
-	list, err := client.GetNamedAPIResourceList(url)
+	updateStateFunc func(previous *string, next *string) error,
+) ([]string, error) {
+	list, err := client.GetNamedAPIResourceList(ctx, url)
 	if err != nil {
-		return fmt.Errorf("unable to get the list of resources: %w", err)
+		return nil, describeRequestError(err, "page", url, "unable to get the list of resources", nil)
 	}
 
 	if updateStateFunc != nil {
-		updateStateFunc(list.Previous, list.Next)
+		if err := updateStateFunc(list.Previous, list.Next); err != nil {
+			return nil, fmt.Errorf("unable to update the state: %w", err)
+		}
 	}
 
-	for _, location := range slices.All(list.Results) {
-		fmt.Println(location.Name)
+	names := make([]string, len(list.Results))
+
+	for ind, location := range slices.All(list.Results) {
+		fmt.Fprintln(out, location.Name)
+
+		names[ind] = location.Name
 	}
 
-	return nil
+	return names, nil
 }
@@ -1,10 +1,49 @@
 package commands
 
-import "codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
 
-func PokedexFunc(trainer *poketrainer.Trainer) CommandFunc {
-	return func(_ []string) error {
-		trainer.ListAllPokemonFromPokedex()
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/render"
+)
+
+func PokedexCommand(trainer *poketrainer.Trainer, renderer *render.Renderer) *Command {
+	return &Command{
+		Name:    "pokedex",
+		Aliases: []string{"dex"},
+		Summary: "List the names and types of all the Pokemon in your Pokedex",
+		Help:    "Lists the names and types of all the Pokemon that you've caught, hatched or received in trades in alphabetical order.",
+		Run:     pokedexFunc(trainer, renderer),
+	}
+}
+
+func pokedexFunc(trainer *poketrainer.Trainer, renderer *render.Renderer) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		names := trainer.PokedexNames()
+		painter := renderer.Painter(out)
+
+		if len(names) == 0 {
+			fmt.Fprintln(out, "You have no Pokemon in your Pokedex.")
+
+			return nil
+		}
+
+		fmt.Fprintln(out, "Your Pokedex:")
+
+		for _, name := range slices.All(names) {
+			pokemon, _ := trainer.GetPokemonFromPokedex(name)
+
+			types := make([]string, len(pokemon.Types))
+
+			for ind, pType := range slices.All(pokemon.Types) {
+				types[ind] = pType.Type.Name
+			}
+
+			fmt.Fprintf(out, "  - %s (%s)\n", name, painter.Types(types))
+		}
 
 		return nil
 	}
@@ -0,0 +1,487 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/fuzzy"
+)
+
+var ErrUnknownCommand = errors.New("unrecognised command")
+
+// Command describes a command that can be run from the REPL or the command line.
+// The dispatcher validates the arguments and flags against the command's
+// declaration before running it.
+type Command struct {
+	Name    string
+	Aliases []string
+
+	// Summary is the one-line description shown in the list of commands.
+	Summary string
+
+	// Help is the detailed description shown by help <command>.
+	Help string
+
+	Args  []Arg
+	Flags []Flag
+
+	// Subcommands are selected by the first argument. A command with
+	// subcommands does not need its own Run function.
+	Subcommands []*Command
+
+	Run CommandFunc
+
+	// Filter is set instead of Run for the built-in filters that read
+	// the output piped into them from the previous command.
+	Filter FilterFunc
+}
+
+// Arg is a positional argument of a command.
+type Arg struct {
+	Name string
+
+	// Description completes the sentence "the <description> has not been specified".
+	Description string
+
+	// Choices are the only values accepted for the argument, if any.
+	Choices []string
+
+	Optional bool
+
+	// Normalise converts the value typed by the user before it is validated.
+	Normalise func(string) string
+
+	// Variadic allows the last argument to be specified more than once.
+	Variadic bool
+}
+
+// FlagKind is the type of value that a flag accepts.
+type FlagKind int
+
+const (
+	FlagBool FlagKind = iota
+	FlagString
+	FlagInt
+)
+
+// Flag is an optional named argument of a command, specified with --name.
+// Flags that are not booleans take a value with --name=value or --name value.
+type Flag struct {
+	Name        string
+	Description string
+	Kind        FlagKind
+	Default     string
+}
+
+// Input is the validated arguments and flags of a command.
+type Input struct {
+	Args  []string
+	flags map[string]string
+}
+
+// Arg returns the positional argument at the index, or an empty
+// string if an optional argument was not specified.
+func (i Input) Arg(index int) string {
+	if index >= len(i.Args) {
+		return ""
+	}
+
+	return i.Args[index]
+}
+
+// Bool returns the value of a boolean flag.
+func (i Input) Bool(name string) bool {
+	return i.flags[name] == "true"
+}
+
+// String returns the value of a string flag.
+func (i Input) String(name string) string {
+	return i.flags[name]
+}
+
+// Int returns the value of an integer flag.
+func (i Input) Int(name string) int {
+	value, _ := strconv.Atoi(i.flags[name])
+
+	return value
+}
+
+// Usage returns the command's usage line.
+func (c *Command) Usage() string {
+	parts := []string{c.Name}
+
+	if len(c.Subcommands) > 0 {
+		names := make([]string, len(c.Subcommands))
+
+		for ind, sub := range slices.All(c.Subcommands) {
+			names[ind] = sub.Name
+		}
+
+		parts = append(parts, strings.Join(names, "|"))
+	}
+
+	for _, flag := range slices.All(c.Flags) {
+		switch flag.Kind {
+		case FlagBool:
+			parts = append(parts, "[--"+flag.Name+"]")
+		case FlagString, FlagInt:
+			parts = append(parts, "[--"+flag.Name+" <"+flag.Name+">]")
+		}
+	}
+
+	for _, arg := range slices.All(c.Args) {
+		name := "<" + arg.Name + ">"
+		if len(arg.Choices) > 0 {
+			name = strings.Join(arg.Choices, "|")
+		}
+
+		if arg.Variadic {
+			name += "..."
+		}
+
+		if arg.Optional {
+			name = "[" + name + "]"
+		}
+
+		parts = append(parts, name)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Registry is the set of commands available to the user.
+type Registry struct {
+	commands []*Command
+	lookup   map[string]*Command
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		commands: make([]*Command, 0),
+		lookup:   make(map[string]*Command),
+	}
+}
+
+// Register adds the commands to the registry. It returns an error if a
+// command's name or alias is already taken.
+func (r *Registry) Register(commands ...*Command) error {
+	for _, cmd := range slices.All(commands) {
+		for _, name := range slices.All(append([]string{cmd.Name}, cmd.Aliases...)) {
+			if _, exists := r.lookup[name]; exists {
+				return fmt.Errorf("the command name %q is already registered", name)
+			}
+
+			r.lookup[name] = cmd
+		}
+
+		r.commands = append(r.commands, cmd)
+	}
+
+	return nil
+}
+
+// Lookup returns the command with the name or alias.
+func (r *Registry) Lookup(name string) (*Command, bool) {
+	cmd, ok := r.lookup[strings.ToLower(name)]
+
+	return cmd, ok
+}
+
+// Commands returns the registered commands sorted by name.
+func (r *Registry) Commands() []*Command {
+	return slices.SortedFunc(slices.Values(r.commands), func(a, b *Command) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// Names returns the names and aliases of all the registered commands.
+func (r *Registry) Names() []string {
+	return slices.Sorted(maps.Keys(r.lookup))
+}
+
+// Run runs the command named by the first argument with the rest of the arguments.
+func (r *Registry) Run(ctx context.Context, out io.Writer, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	cmd, input, err := r.resolve(args)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Run == nil {
+		if cmd.Filter != nil {
+			return fmt.Errorf("the %s command can only read the output piped into it (e.g. pokedex | %s)", cmd.Name, cmd.Name)
+		}
+
+		return fmt.Errorf("the %s command is defined but does not have a run function", cmd.Name)
+	}
+
+	return cmd.Run(ctx, out, input)
+}
+
+// RunPipeline runs the command in the first stage of the pipeline and passes
+// its output through the filters in the remaining stages. Every stage is
+// validated before the command is run.
+func (r *Registry) RunPipeline(ctx context.Context, out io.Writer, pipeline [][]string) error {
+	if len(pipeline) < 2 {
+		if len(pipeline) == 0 {
+			return nil
+		}
+
+		return r.Run(ctx, out, pipeline[0])
+	}
+
+	filters := make([]*Command, len(pipeline)-1)
+	inputs := make([]Input, len(pipeline)-1)
+
+	for ind, stage := range slices.All(pipeline[1:]) {
+		cmd, input, err := r.resolve(stage)
+		if err != nil {
+			return err
+		}
+
+		if cmd.Filter == nil {
+			return fmt.Errorf("the output cannot be piped into the %s command", cmd.Name)
+		}
+
+		filters[ind], inputs[ind] = cmd, input
+	}
+
+	output := new(bytes.Buffer)
+
+	if err := r.Run(ctx, output, pipeline[0]); err != nil {
+		return err
+	}
+
+	for ind, filter := range slices.All(filters) {
+		filtered := new(bytes.Buffer)
+
+		if err := filter.Filter(output, filtered, inputs[ind]); err != nil {
+			return err
+		}
+
+		output = filtered
+	}
+
+	_, err := output.WriteTo(out)
+
+	return err
+}
+
+// resolve finds the command, or the subcommand, that the arguments refer
+// to and validates the rest of the arguments against its declaration.
+func (r *Registry) resolve(args []string) (*Command, Input, error) {
+	cmd, ok := r.Lookup(args[0])
+	if !ok {
+		return nil, Input{}, fmt.Errorf(
+			"%w '%s'%s",
+			ErrUnknownCommand,
+			args[0],
+			suggestionHint(func(name string) []string {
+				return fuzzy.Suggest(name, r.Names(), maxSuggestions)
+			}, strings.ToLower(args[0])),
+		)
+	}
+
+	return cmd.resolve(args[1:])
+}
+
+func (c *Command) resolve(args []string) (*Command, Input, error) {
+	if len(c.Subcommands) > 0 {
+		names := make([]string, len(c.Subcommands))
+
+		for ind, sub := range slices.All(c.Subcommands) {
+			names[ind] = sub.Name
+		}
+
+		if len(args) == 0 {
+			return nil, Input{}, fmt.Errorf("the %s action has not been specified (%s)", c.Name, joinChoices(names))
+		}
+
+		if sub := c.subcommand(args[0]); sub != nil {
+			return sub.resolve(args[1:])
+		}
+
+		return nil, Input{}, fmt.Errorf("unknown %s action %q: want %s", c.Name, args[0], joinChoices(names))
+	}
+
+	input, err := c.parse(args)
+	if err != nil {
+		return nil, Input{}, err
+	}
+
+	return c, input, nil
+}
+
+// subcommand returns the subcommand with the name or alias, or nil
+// if there is no such subcommand.
+func (c *Command) subcommand(name string) *Command {
+	name = strings.ToLower(name)
+
+	for _, sub := range slices.All(c.Subcommands) {
+		if sub.Name == name || slices.Contains(sub.Aliases, name) {
+			return sub
+		}
+	}
+
+	return nil
+}
+
+// parse separates the flags from the positional arguments and validates both.
+func (c *Command) parse(args []string) (Input, error) {
+	input := Input{
+		Args:  make([]string, 0, len(args)),
+		flags: make(map[string]string),
+	}
+
+	for _, flag := range slices.All(c.Flags) {
+		if flag.Default != "" {
+			input.flags[flag.Name] = flag.Default
+		}
+	}
+
+	for ind := 0; ind < len(args); ind++ {
+		arg := args[ind]
+
+		if arg == "--" {
+			input.Args = append(input.Args, args[ind+1:]...)
+
+			break
+		}
+
+		if !strings.HasPrefix(arg, "--") {
+			input.Args = append(input.Args, arg)
+
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+
+		flagIndex := slices.IndexFunc(c.Flags, func(flag Flag) bool { return flag.Name == name })
+		if flagIndex < 0 {
+			return Input{}, fmt.Errorf("unknown flag --%s for the %s command", name, c.Name)
+		}
+
+		flag := c.Flags[flagIndex]
+
+		switch {
+		case flag.Kind == FlagBool && !hasValue:
+			value = "true"
+		case !hasValue:
+			if ind+1 >= len(args) {
+				return Input{}, fmt.Errorf("the flag --%s needs a value", name)
+			}
+
+			ind++
+			value = args[ind]
+		}
+
+		normalised, err := flag.normalise(value)
+		if err != nil {
+			return Input{}, err
+		}
+
+		input.flags[name] = normalised
+	}
+
+	for ind, value := range slices.All(input.Args) {
+		if len(c.Args) == 0 {
+			break
+		}
+
+		if normalise := c.Args[min(ind, len(c.Args)-1)].Normalise; normalise != nil {
+			input.Args[ind] = normalise(value)
+		}
+	}
+
+	if err := c.validateArgs(input.Args); err != nil {
+		return Input{}, err
+	}
+
+	return input, nil
+}
+
+// normalise validates the flag's value and returns it in its canonical form.
+func (f Flag) normalise(value string) (string, error) {
+	switch f.Kind {
+	case FlagBool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("the flag --%s must be true or false: got %q", f.Name, value)
+		}
+
+		return strconv.FormatBool(parsed), nil
+	case FlagInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", fmt.Errorf("the flag --%s must be a whole number: got %q", f.Name, value)
+		}
+	case FlagString:
+	}
+
+	return value, nil
+}
+
+func (c *Command) validateArgs(args []string) error {
+	required, variadic := 0, false
+
+	for _, arg := range slices.All(c.Args) {
+		if !arg.Optional {
+			required++
+		}
+
+		variadic = variadic || arg.Variadic
+	}
+
+	if len(args) < required {
+		missing := c.Args[len(args)]
+
+		return fmt.Errorf("the %s has not been specified", missing.Description)
+	}
+
+	if !variadic && len(args) > len(c.Args) {
+		return fmt.Errorf(
+			"unexpected number of arguments: want %s (%s); got %d",
+			wantArgs(required, len(c.Args)),
+			c.Usage(),
+			len(args),
+		)
+	}
+
+	for ind, value := range slices.All(args) {
+		arg := c.Args[min(ind, len(c.Args)-1)]
+
+		if len(arg.Choices) > 0 && !slices.Contains(arg.Choices, value) {
+			return fmt.Errorf("unknown %s %q: want %s", arg.Name, value, joinChoices(arg.Choices))
+		}
+	}
+
+	return nil
+}
+
+func wantArgs(required, total int) string {
+	if required == total {
+		return strconv.Itoa(total)
+	}
+
+	return fmt.Sprintf("%d to %d", required, total)
+}
+
+// joinChoices joins the choices in a sentence, e.g. "a, b or c".
+func joinChoices(choices []string) string {
+	if len(choices) < 2 {
+		return strings.Join(choices, "")
+	}
+
+	last := len(choices) - 1
+
+	return strings.Join(choices[:last], ", ") + " or " + choices[last]
+}
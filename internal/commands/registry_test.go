@@ -0,0 +1,344 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/commands"
+)
+
+// recorder is a command that records the input that it was run with.
+type recorder struct {
+	input commands.Input
+	runs  int
+}
+
+func (r *recorder) run(_ context.Context, _ io.Writer, input commands.Input) error {
+	r.input = input
+	r.runs++
+
+	return nil
+}
+
+func testRegistry(t *testing.T, rec *recorder) *commands.Registry {
+	t.Helper()
+
+	registry := commands.NewRegistry()
+
+	err := registry.Register(
+		&commands.Command{
+			Name:    "battle",
+			Aliases: []string{"fight"},
+			Summary: "Battle with a Pokemon",
+			Args: []commands.Arg{
+				{Name: "mode", Description: "battle mode", Choices: []string{"single", "double"}, Normalise: strings.ToLower},
+				{Name: "pokemon", Description: "name of the Pokemon"},
+				{Name: "level", Description: "level of the Pokemon", Optional: true},
+			},
+			Flags: []commands.Flag{
+				{Name: "quick", Description: "Skip the animations", Kind: commands.FlagBool},
+				{Name: "turns", Description: "The maximum number of turns", Kind: commands.FlagInt, Default: "10"},
+			},
+			Run: rec.run,
+		},
+		&commands.Command{
+			Name:    "box",
+			Summary: "Manage the PC box",
+			Subcommands: []*commands.Command{
+				{
+					Name:    "store",
+					Summary: "Store Pokemon in the box",
+					Args:    []commands.Arg{{Name: "pokemon", Description: "name of the Pokemon", Variadic: true}},
+					Run:     rec.run,
+				},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Unable to register the commands: %v", err)
+	}
+
+	if err := registry.Register(commands.HelpCommand(registry)); err != nil {
+		t.Fatalf("Unable to register the help command: %v", err)
+	}
+
+	return registry
+}
+
+func TestRegistryRun(t *testing.T) {
+	cases := []struct {
+		name      string
+		args      []string
+		wantArgs  []string
+		wantQuick bool
+		wantTurns int
+	}{
+		{
+			name:      "Required arguments",
+			args:      []string{"battle", "single", "pikachu"},
+			wantArgs:  []string{"single", "pikachu"},
+			wantQuick: false,
+			wantTurns: 10,
+		},
+		{
+			name:      "Alias with optional argument and flags",
+			args:      []string{"FIGHT", "--quick", "Double", "pikachu", "--turns", "3", "50"},
+			wantArgs:  []string{"double", "pikachu", "50"},
+			wantQuick: true,
+			wantTurns: 3,
+		},
+		{
+			name:      "Flag values after an equals sign",
+			args:      []string{"battle", "--quick=false", "--turns=5", "single", "pikachu"},
+			wantArgs:  []string{"single", "pikachu"},
+			wantQuick: false,
+			wantTurns: 5,
+		},
+		{
+			name:      "Subcommand with variadic arguments",
+			args:      []string{"box", "store", "pikachu", "eevee", "--", "--snorlax"},
+			wantArgs:  []string{"pikachu", "eevee", "--snorlax"},
+			wantQuick: false,
+			wantTurns: 0,
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			var rec recorder
+
+			registry := testRegistry(t, &rec)
+
+			if err := registry.Run(context.Background(), io.Discard, testcase.args); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if rec.runs != 1 {
+				t.Fatalf("Unexpected number of runs: want 1, got %d", rec.runs)
+			}
+
+			if !slices.Equal(rec.input.Args, testcase.wantArgs) {
+				t.Errorf("Unexpected arguments: want %v, got %v", testcase.wantArgs, rec.input.Args)
+			}
+
+			if got := rec.input.Bool("quick"); got != testcase.wantQuick {
+				t.Errorf("Unexpected value of --quick: want %t, got %t", testcase.wantQuick, got)
+			}
+
+			if got := rec.input.Int("turns"); got != testcase.wantTurns {
+				t.Errorf("Unexpected value of --turns: want %d, got %d", testcase.wantTurns, got)
+			}
+		})
+	}
+}
+
+func TestRegistryValidation(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "Missing argument",
+			args: []string{"battle", "single"},
+			want: "the name of the Pokemon has not been specified",
+		},
+		{
+			name: "Too many arguments",
+			args: []string{"battle", "single", "pikachu", "50", "eevee"},
+			want: "unexpected number of arguments: want 2 to 3 (battle [--quick] [--turns <turns>] single|double <pokemon> [<level>]); got 4",
+		},
+		{
+			name: "Invalid choice",
+			args: []string{"battle", "triple", "pikachu"},
+			want: `unknown mode "triple": want single or double`,
+		},
+		{
+			name: "Unknown flag",
+			args: []string{"battle", "--slow", "single", "pikachu"},
+			want: "unknown flag --slow for the battle command",
+		},
+		{
+			name: "Invalid flag value",
+			args: []string{"battle", "--turns", "many", "single", "pikachu"},
+			want: `the flag --turns must be a whole number: got "many"`,
+		},
+		{
+			name: "Missing subcommand",
+			args: []string{"box"},
+			want: "the box action has not been specified (store)",
+		},
+		{
+			name: "Unknown subcommand",
+			args: []string{"box", "release"},
+			want: `unknown box action "release": want store`,
+		},
+		{
+			name: "Unknown command",
+			args: []string{"batle"},
+			want: "unrecognised command 'batle' (did you mean battle?)",
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			var rec recorder
+
+			registry := testRegistry(t, &rec)
+
+			err := registry.Run(context.Background(), io.Discard, testcase.args)
+			if err == nil {
+				t.Fatal("Expected an error but got none")
+			}
+
+			if got := err.Error(); got != testcase.want {
+				t.Errorf("Unexpected error message: want %q, got %q", testcase.want, got)
+			}
+
+			if rec.runs != 0 {
+				t.Errorf("The command was run after failing validation")
+			}
+		})
+	}
+
+	var rec recorder
+
+	if err := testRegistry(t, &rec).Run(context.Background(), io.Discard, []string{"batle"}); !errors.Is(err, commands.ErrUnknownCommand) {
+		t.Errorf("Unexpected error for an unknown command: want %v, got %v", commands.ErrUnknownCommand, err)
+	}
+}
+
+func TestHelpCommand(t *testing.T) {
+	var (
+		rec recorder
+		out bytes.Buffer
+	)
+
+	registry := testRegistry(t, &rec)
+
+	if err := registry.Run(context.Background(), &out, []string{"help", "battle"}); err != nil {
+		t.Fatalf("Unable to display the help for the battle command: %v", err)
+	}
+
+	for _, want := range []string{
+		"Usage: battle [--quick] [--turns <turns>] single|double <pokemon> [<level>]",
+		"Aliases: fight",
+		"<level>    The level of the Pokemon (optional)",
+		"--turns  The maximum number of turns",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("The help message does not contain %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+
+	if err := registry.Run(context.Background(), &out, []string{"help", "box", "store"}); err != nil {
+		t.Fatalf("Unable to display the help for the box store action: %v", err)
+	}
+
+	if want := "Usage: box store <pokemon>..."; !strings.Contains(out.String(), want) {
+		t.Errorf("The help message does not contain %q:\n%s", want, out.String())
+	}
+}
+
+func TestRunPipeline(t *testing.T) {
+	registry := commands.NewRegistry()
+
+	err := registry.Register(
+		&commands.Command{
+			Name:    "pokedex",
+			Summary: "List the Pokemon in the Pokedex",
+			Run: func(_ context.Context, out io.Writer, _ commands.Input) error {
+				fmt.Fprint(out, "Your Pokedex:\n  - charmander (fire)\n  - magmar (fire)\n  - squirtle (water)\n")
+
+				return nil
+			},
+		},
+		commands.GrepCommand(),
+		commands.CountCommand(),
+	)
+	if err != nil {
+		t.Fatalf("Unable to register the commands: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		pipeline [][]string
+		want     string
+	}{
+		{
+			name:     "Grep",
+			pipeline: [][]string{{"pokedex"}, {"grep", "fire"}},
+			want:     "  - charmander (fire)\n  - magmar (fire)\n",
+		},
+		{
+			name:     "Grep ignoring the case and inverting the match",
+			pipeline: [][]string{{"pokedex"}, {"grep", "--invert", "--ignore-case", "FIRE"}},
+			want:     "Your Pokedex:\n  - squirtle (water)\n",
+		},
+		{
+			name:     "Count",
+			pipeline: [][]string{{"pokedex"}, {"count"}},
+			want:     "3\n",
+		},
+		{
+			name:     "Grep then count",
+			pipeline: [][]string{{"pokedex"}, {"grep", "fire"}, {"count"}},
+			want:     "2\n",
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			if err := registry.RunPipeline(context.Background(), &out, testcase.pipeline); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got := out.String(); got != testcase.want {
+				t.Errorf("Unexpected output: want %q, got %q", testcase.want, got)
+			}
+		})
+	}
+
+	for _, testcase := range []struct {
+		pipeline [][]string
+		want     string
+	}{
+		{
+			pipeline: [][]string{{"pokedex"}, {"pokedex"}},
+			want:     "the output cannot be piped into the pokedex command",
+		},
+		{
+			pipeline: [][]string{{"count"}},
+			want:     "the count command can only read the output piped into it (e.g. pokedex | count)",
+		},
+	} {
+		err := registry.RunPipeline(context.Background(), io.Discard, testcase.pipeline)
+		if err == nil || err.Error() != testcase.want {
+			t.Errorf("Unexpected error after running %q: want %q, got %v", testcase.pipeline, testcase.want, err)
+		}
+	}
+}
+
+func TestExitCommand(t *testing.T) {
+	registry := commands.NewRegistry()
+
+	if err := registry.Register(commands.ExitCommand()); err != nil {
+		t.Fatalf("Unable to register the exit command: %v", err)
+	}
+
+	for _, name := range []string{"exit", "quit"} {
+		if err := registry.Run(context.Background(), io.Discard, []string{name}); !errors.Is(err, commands.ErrExit) {
+			t.Errorf("Unexpected error after running %s: want %v, got %v", name, commands.ErrExit, err)
+		}
+	}
+}
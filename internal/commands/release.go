@@ -1,37 +1,38 @@
 package commands
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"io"
 
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
 )
 
-func ReleaseFunc(trainer *poketrainer.Trainer) CommandFunc {
-	return func(args []string) error {
-		if args == nil {
-			return errors.New("the name of the Pokemon has not been specified")
-		}
-
-		if len(args) != 1 {
-			return fmt.Errorf(
-				"unexpected number of Pokemon names: want 1; got %d",
-				len(args),
-			)
-		}
+func ReleaseCommand(trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "release",
+		Summary: "Release a Pokemon back into the wild",
+		Help:    "Releases a Pokemon from your Pokedex back into the wild. Use undo if you change your mind.",
+		Args:    []Arg{pokemonArg},
+		Run:     releaseFunc(trainer),
+	}
+}
 
-		pokemonName := args[0]
+func releaseFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		pokemonName := input.Arg(0)
 
 		if _, caught := trainer.GetPokemonFromPokedex(pokemonName); !caught {
-			return fmt.Errorf(
-				"you haven't caught a %s",
-				pokemonName,
-			)
+			return notCaughtError(trainer, pokemonName)
+		}
+
+		if err := trainer.RemovePokemonFromPokedex(pokemonName); err != nil {
+			return fmt.Errorf("unable to release %s: %w", pokemonName, err)
 		}
 
-		trainer.RemovePokemonFromPokedex(pokemonName)
+		fmt.Fprintf(out, "%s was released back into the wild.\n", pokemonName)
 
-		fmt.Printf("%s was released back into the wild.\n", pokemonName)
+		printNewAchievements(out, trainer)
 
 		return nil
 	}
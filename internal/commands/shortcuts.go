@@ -0,0 +1,272 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/cmdline"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/shortcuts"
+)
+
+var shortcutNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+func AliasCommand(shortcutSet *shortcuts.Shortcuts, registry *Registry) *Command {
+	nameArg := Arg{Name: "name", Description: "name of the alias", Normalise: strings.ToLower}
+
+	return &Command{
+		Name:    "alias",
+		Summary: "Manage the short names for commands",
+		Help:    "An alias is a short name for a command and its arguments. Any arguments typed after the alias are added to the end of the command. Use -- before the command if it has flags, e.g. alias add fire -- grep --ignore-case fire.",
+		Subcommands: []*Command{
+			{
+				Name:    "add",
+				Summary: "Create or replace an alias",
+				Args: []Arg{
+					nameArg,
+					{Name: "command", Description: "command that the alias runs", Variadic: true},
+				},
+				Run: aliasAddFunc(shortcutSet, registry),
+			},
+			{
+				Name:    "remove",
+				Summary: "Remove an alias",
+				Args:    []Arg{nameArg},
+				Run:     aliasRemoveFunc(shortcutSet),
+			},
+			{
+				Name:    "list",
+				Summary: "List the aliases",
+				Run:     aliasListFunc(shortcutSet),
+			},
+		},
+	}
+}
+
+func MacroCommand(shortcutSet *shortcuts.Shortcuts, registry *Registry) *Command {
+	nameArg := Arg{Name: "name", Description: "name of the macro", Normalise: strings.ToLower}
+
+	return &Command{
+		Name:    "macro",
+		Summary: "Record sequences of commands and replay them by name",
+		Help:    "Every command line that runs without errors while a macro is being recorded is added to the macro. Type the name of the macro to run the recorded commands again; the macro stops at the first command that fails.",
+		Subcommands: []*Command{
+			{
+				Name:    "record",
+				Summary: "Start recording a macro",
+				Args:    []Arg{nameArg},
+				Run:     macroRecordFunc(shortcutSet, registry),
+			},
+			{
+				Name:    "stop",
+				Summary: "Stop recording and save the macro",
+				Run:     macroStopFunc(shortcutSet),
+			},
+			{
+				Name:    "show",
+				Summary: "Display the commands recorded in a macro",
+				Args:    []Arg{nameArg},
+				Run:     macroShowFunc(shortcutSet),
+			},
+			{
+				Name:    "delete",
+				Summary: "Delete a macro",
+				Args:    []Arg{nameArg},
+				Run:     macroDeleteFunc(shortcutSet),
+			},
+			{
+				Name:    "list",
+				Summary: "List the macros",
+				Run:     macroListFunc(shortcutSet),
+			},
+		},
+	}
+}
+
+func aliasAddFunc(shortcutSet *shortcuts.Shortcuts, registry *Registry) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		name, words := input.Arg(0), input.Args[1:]
+
+		if err := validateShortcutName(registry, name); err != nil {
+			return err
+		}
+
+		if _, ok := registry.Lookup(words[0]); !ok {
+			return fmt.Errorf("%w '%s'", ErrUnknownCommand, words[0])
+		}
+
+		if err := shortcutSet.SetAlias(name, words); err != nil {
+			return fmt.Errorf("unable to set the %s alias: %w", name, err)
+		}
+
+		fmt.Fprintf(out, "The %s alias now runs: %s\n", name, cmdline.Join(words))
+
+		return nil
+	}
+}
+
+func aliasRemoveFunc(shortcutSet *shortcuts.Shortcuts) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		name := input.Arg(0)
+
+		if err := shortcutSet.RemoveAlias(name); err != nil {
+			return fmt.Errorf("unable to remove the %s alias: %w", name, err)
+		}
+
+		fmt.Fprintf(out, "The %s alias has been removed.\n", name)
+
+		return nil
+	}
+}
+
+func aliasListFunc(shortcutSet *shortcuts.Shortcuts) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		names := shortcutSet.AliasNames()
+
+		if len(names) == 0 {
+			fmt.Fprintln(out, "You have no aliases.")
+
+			return nil
+		}
+
+		var builder strings.Builder
+
+		builder.WriteString("Aliases:\n")
+
+		tableWriter := tabwriter.NewWriter(&builder, 0, 8, 2, ' ', 0)
+
+		for _, name := range slices.All(names) {
+			words, _ := shortcutSet.Alias(name)
+
+			fmt.Fprintf(tableWriter, "  - %s\t%s\n", name, cmdline.Join(words))
+		}
+
+		tableWriter.Flush()
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+func macroRecordFunc(shortcutSet *shortcuts.Shortcuts, registry *Registry) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		name := input.Arg(0)
+
+		if err := validateShortcutName(registry, name); err != nil {
+			return err
+		}
+
+		if err := shortcutSet.StartRecording(name); err != nil {
+			return fmt.Errorf("unable to record the %s macro: %w", name, err)
+		}
+
+		fmt.Fprintf(out, "Recording the %s macro. Use 'macro stop' to save it.\n", name)
+
+		return nil
+	}
+}
+
+func macroStopFunc(shortcutSet *shortcuts.Shortcuts) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		name, lines, err := shortcutSet.StopRecording()
+		if err != nil {
+			return fmt.Errorf("unable to save the macro: %w", err)
+		}
+
+		if lines == 0 {
+			fmt.Fprintf(out, "No commands were recorded so the %s macro was not saved.\n", name)
+
+			return nil
+		}
+
+		fmt.Fprintf(out, "The %s macro was saved with %d command line(s). Type %s to run it.\n", name, lines, name)
+
+		return nil
+	}
+}
+
+func macroShowFunc(shortcutSet *shortcuts.Shortcuts) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		name := input.Arg(0)
+
+		lines, ok := shortcutSet.Macro(name)
+		if !ok {
+			return fmt.Errorf("there is no macro called %q", name)
+		}
+
+		var builder strings.Builder
+
+		builder.WriteString("The " + name + " macro runs:\n")
+
+		for ind, line := range slices.All(lines) {
+			fmt.Fprintf(&builder, "  %d. %s\n", ind+1, line)
+		}
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+func macroDeleteFunc(shortcutSet *shortcuts.Shortcuts) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		name := input.Arg(0)
+
+		if err := shortcutSet.RemoveMacro(name); err != nil {
+			return fmt.Errorf("unable to delete the %s macro: %w", name, err)
+		}
+
+		fmt.Fprintf(out, "The %s macro has been deleted.\n", name)
+
+		return nil
+	}
+}
+
+func macroListFunc(shortcutSet *shortcuts.Shortcuts) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		names := shortcutSet.MacroNames()
+
+		if len(names) == 0 {
+			fmt.Fprintln(out, "You have no macros.")
+
+			return nil
+		}
+
+		var builder strings.Builder
+
+		builder.WriteString("Macros:\n")
+
+		for _, name := range slices.All(names) {
+			lines, _ := shortcutSet.Macro(name)
+
+			fmt.Fprintf(&builder, "  - %s (%d command line(s))\n", name, len(lines))
+		}
+
+		if name, ok := shortcutSet.Recording(); ok {
+			fmt.Fprintf(&builder, "\nThe %s macro is being recorded.\n", name)
+		}
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
+
+// validateShortcutName checks that the name of an alias or macro is a
+// single word that does not hide one of the commands.
+func validateShortcutName(registry *Registry, name string) error {
+	if !shortcutNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid name %q: use lowercase letters, digits, hyphens and underscores", name)
+	}
+
+	if cmd, ok := registry.Lookup(name); ok {
+		return fmt.Errorf("%q is already the name of the %s command", name, cmd.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func StatsCommand(trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "stats",
+		Summary: "Display your trainer statistics",
+		Help:    "Displays your lifetime statistics, the types of Pokemon you've collected and the location areas where you've caught every Pokemon.",
+		Run:     statsFunc(trainer),
+	}
+}
+
+func statsFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		stats := trainer.Statistics()
+
+		var builder strings.Builder
+
+		builder.WriteString("Trainer statistics:\n")
+
+		tableWriter := tabwriter.NewWriter(&builder, 0, 8, 1, ' ', 0)
+
+		fmt.Fprintf(tableWriter, "  Throws attempted:\t%d\n", stats.ThrowsAttempted)
+		fmt.Fprintf(tableWriter, "  Catches:\t%d\n", stats.Catches)
+		fmt.Fprintf(tableWriter, "  Escapes:\t%d\n", stats.Escapes)
+		fmt.Fprintf(tableWriter, "  Releases:\t%d\n", stats.Releases)
+		fmt.Fprintf(tableWriter, "  Areas visited:\t%d\n", stats.AreasVisited)
+		fmt.Fprintf(tableWriter, "  Unique species caught:\t%d\n", stats.SpeciesCaught)
+		fmt.Fprintf(tableWriter, "  Types collected:\t%d/%d\n", stats.TypesCollected, stats.TotalTypes)
+
+		tableWriter.Flush()
+
+		if types := trainer.CollectedTypes(); len(types) > 0 {
+			builder.WriteString("Collected types:\n")
+
+			for _, pType := range slices.All(types) {
+				builder.WriteString("  - " + pType + "\n")
+			}
+		}
+
+		if areas := trainer.CompletedLocationAreas(); len(areas) > 0 {
+			builder.WriteString("Completed location areas:\n")
+
+			for _, area := range slices.All(areas) {
+				builder.WriteString("  - " + area + "\n")
+			}
+		}
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
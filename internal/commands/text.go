@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+)
+
+// language is the language of the text shown from the PokeAPI.
+const language = "en"
+
+// englishEffect returns the effect and short effect from the English entry.
+func englishEffect(entries []pokeapi.VerboseEffect) (string, string, bool) {
+	for _, entry := range slices.All(entries) {
+		if entry.Language.Name == language {
+			return entry.Effect, entry.ShortEffect, true
+		}
+	}
+
+	return "", "", false
+}
+
+// writeEffect writes the short effect and the effect, if they are known.
+func writeEffect(builder *strings.Builder, effect, shortEffect string) {
+	if shortEffect != "" {
+		builder.WriteString("Short effect: " + shortEffect + "\n")
+	}
+
+	if effect != "" {
+		builder.WriteString("Effect:\n" + indent(effect, "  ") + "\n")
+	}
+}
+
+// flattenText joins the lines of the flavour text from the games,
+// which are broken to fit in the games' text boxes.
+func flattenText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// indent indents every non-empty line of the text with the prefix.
+func indent(text, prefix string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+
+	for ind, line := range slices.All(lines) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines[ind] = prefix + line
+		} else {
+			lines[ind] = ""
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
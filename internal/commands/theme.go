@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/render"
+)
+
+func ThemeCommand(renderer *render.Renderer) *Command {
+	return &Command{
+		Name:    "theme",
+		Summary: "List the colour themes or change the theme",
+		Help: "Lists the colour themes and a sample of the type colours in the current theme when no theme is specified. " +
+			"The output is only coloured in terminals that support colours and when NO_COLOR is not set.",
+		Args: []Arg{
+			{
+				Name:        "theme",
+				Description: "name of the theme to use",
+				Choices:     render.ThemeNames(),
+				Optional:    true,
+				Normalise:   strings.ToLower,
+			},
+		},
+		Run: themeFunc(renderer),
+	}
+}
+
+func themeFunc(renderer *render.Renderer) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		if name := input.Arg(0); name != "" {
+			theme, _ := render.LookupTheme(name)
+			renderer.SetTheme(theme)
+
+			fmt.Fprintf(out, "The theme is now %s.\n", theme.Name)
+
+			return nil
+		}
+
+		var builder strings.Builder
+
+		builder.WriteString("Themes:\n")
+
+		tableWriter := tabwriter.NewWriter(&builder, 0, 8, 2, ' ', 0)
+		current := renderer.Theme().Name
+
+		for _, theme := range slices.All(render.Themes()) {
+			marker := " "
+			if theme.Name == current {
+				marker = "*"
+			}
+
+			fmt.Fprintf(tableWriter, "  %s %s\t%s\n", marker, theme.Name, theme.Description)
+		}
+
+		tableWriter.Flush()
+
+		painter := renderer.Painter(out)
+
+		builder.WriteString("Sample: " + painter.Types([]string{"fire", "water", "grass", "electric"}) + "\n")
+
+		fmt.Fprint(out, builder.String())
+
+		return nil
+	}
+}
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrade"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func TradeCommand(trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "trade",
+		Summary: "Trade a Pokemon with another trainer using a trade file",
+		Help:    "Exporting packs a Pokemon from your Pokedex, along with its held item and individual traits, into a trade file that another trainer can import. The file has a checksum to detect accidental corruption.",
+		Subcommands: []*Command{
+			{
+				Name:    "export",
+				Summary: "Pack a Pokemon into a trade file and remove it from your Pokedex",
+				Args:    []Arg{pokemonArg, tradeFileArg},
+				Run:     tradeExportFunc(trainer),
+			},
+			{
+				Name:    "import",
+				Summary: "Receive the Pokemon from a trade file",
+				Args:    []Arg{tradeFileArg},
+				Run:     tradeImportFunc(trainer),
+			},
+		},
+	}
+}
+
+var tradeFileArg = Arg{Name: "file", Description: "trade file"}
+
+func tradeExportFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		pokemonName, path := input.Arg(0), input.Arg(1)
+
+		pokemon, ok := trainer.GetPokemonFromPokedex(pokemonName)
+		if !ok {
+			return notCaughtError(trainer, pokemonName)
+		}
+
+		pkg, err := poketrade.NewPackage(tradedPokemon(trainer, pokemonName, pokemon))
+		if err != nil {
+			return fmt.Errorf("unable to package %s for trading: %w", pokemonName, err)
+		}
+
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("unable to create the trade file: %w", err)
+		}
+
+		if err := poketrade.Write(file, pkg); err != nil {
+			_ = file.Close()
+			_ = os.Remove(path)
+
+			return fmt.Errorf("unable to write the trade file: %w", err)
+		}
+
+		if err := file.Close(); err != nil {
+			_ = os.Remove(path)
+
+			return fmt.Errorf("unable to close the trade file: %w", err)
+		}
+
+		if err := trainer.TradeAwayPokemon(pokemonName); err != nil {
+			_ = os.Remove(path)
+
+			return fmt.Errorf("unable to trade away %s: %w", pokemonName, err)
+		}
+
+		fmt.Fprintf(out, "%s was packed into %s and is ready to be traded.\n", pokemonName, path)
+
+		return nil
+	}
+}
+
+func tradeImportFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, input Input) error {
+		path := input.Arg(0)
+
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("unable to open the trade file: %w", err)
+		}
+		defer file.Close()
+
+		pkg, err := poketrade.Read(file)
+		if err != nil {
+			return fmt.Errorf("unable to read the trade file: %w", err)
+		}
+
+		received, err := pkg.Unpack()
+		if err != nil {
+			return fmt.Errorf("the trade file is invalid: %w", err)
+		}
+
+		if err := trainer.ReceiveTradedPokemon(received.Name, received.Pokemon, received.Individual, received.HeldItem); err != nil {
+			return fmt.Errorf("unable to receive %s: %w", received.Name, err)
+		}
+
+		fmt.Fprintf(out, "%s was received from the trade and added to your Pokedex!\n", received.Name)
+
+		return nil
+	}
+}
+
+// tradedPokemon returns the Pokemon from the trainer's Pokedex along with its
+// individual traits and held item so that they can be packaged for trading.
+func tradedPokemon(trainer *poketrainer.Trainer, name string, pokemon pokeapi.Pokemon) poketrade.TradedPokemon {
+	traded := poketrade.TradedPokemon{
+		Name:       name,
+		Pokemon:    pokemon,
+		Individual: nil,
+		HeldItem:   "",
+	}
+
+	if individual, ok := trainer.Individual(name); ok {
+		traded.Individual = &individual
+	}
+
+	if heldItem, ok := trainer.HeldItem(name); ok {
+		traded.HeldItem = heldItem
+	}
+
+	return traded
+}
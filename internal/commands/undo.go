@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func UndoCommand(trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "undo",
+		Summary: "Undo the last change to your Pokedex, location or map page",
+		Help:    "Undoes the last catch, release, visit or map page change. Trades and daycare visits cannot be undone.",
+		Run:     undoFunc(trainer),
+	}
+}
+
+func RedoCommand(trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "redo",
+		Summary: "Redo the last change that was undone",
+		Help:    "Redoes the last change that was undone. Making a new change after an undo clears the changes that can be redone.",
+		Run:     redoFunc(trainer),
+	}
+}
+
+func undoFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		event, err := trainer.Undo()
+		if err != nil {
+			return fmt.Errorf("unable to undo: %w", err)
+		}
+
+		fmt.Fprintf(out, "Undone: %s\n", event)
+
+		return nil
+	}
+}
+
+func redoFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(_ context.Context, out io.Writer, _ Input) error {
+		event, err := trainer.Redo()
+		if err != nil {
+			return fmt.Errorf("unable to redo: %w", err)
+		}
+
+		fmt.Fprintf(out, "Redone: %s\n", event)
+
+		return nil
+	}
+}
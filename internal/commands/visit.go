@@ -1,39 +1,48 @@
 package commands
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"io"
 
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
 )
 
-func VisitFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
-	return func(args []string) error {
-		if args == nil {
-			return errors.New("the location area has not been specified")
-		}
-
-		if len(args) != 1 {
-			return fmt.Errorf(
-				"unexpected number of location areas: want 1; got %d",
-				len(args),
-			)
-		}
+func VisitCommand(client *pokeclient.Client, trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "visit",
+		Summary: "Visit a location area",
+		Help:    "Travels to a location area so that you can explore it and catch the Pokemon that live there. Use map to find location areas.",
+		Args: []Arg{
+			{Name: "location-area", Description: "location area", Normalise: resourceName},
+		},
+		Run: visitFunc(client, trainer),
+	}
+}
 
-		locationAreaName := args[0]
+func visitFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		locationAreaName := input.Arg(0)
 
-		locationArea, err := client.GetLocationArea(locationAreaName)
+		locationArea, err := client.GetLocationArea(ctx, locationAreaName)
 		if err != nil {
-			return fmt.Errorf(
-				"unable to get the location area: %w",
+			return describeRequestError(
 				err,
+				"location area",
+				locationAreaName,
+				"unable to get the location area",
+				resourceSuggester(ctx, client, pokeclient.LocationAreaResource),
 			)
 		}
 
-		trainer.UpdateCurrentLocationAreaName(locationArea.Name)
+		if err := trainer.UpdateCurrentLocationArea(locationArea); err != nil {
+			return fmt.Errorf("unable to update the current location area: %w", err)
+		}
+
+		fmt.Fprintln(out, "You are now visiting", locationArea.Name)
 
-		fmt.Println("You are now visiting", locationArea.Name)
+		printNewAchievements(out, trainer)
 
 		return nil
 	}
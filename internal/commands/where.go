@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func WhereCommand(client *pokeclient.Client, trainer *poketrainer.Trainer) *Command {
+	return &Command{
+		Name:    "where",
+		Summary: "List the location areas where a Pokemon can be found",
+		Help: "Lists every location area where a Pokemon can be encountered in the wild, grouped by the version of the games " +
+			"and the encounter method, along with the range of levels and the chance of encountering it with each method. " +
+			"Location areas where the Pokemon can be encountered with more than one method also list the total chance for the version.",
+		Args: []Arg{pokemonArg},
+		Flags: []Flag{
+			{Name: "version", Description: "Only list the location areas in this version of the games", Kind: FlagString},
+			{Name: "visited", Description: "Mark the location areas that you have visited", Kind: FlagBool},
+		},
+		Run: whereFunc(client, trainer),
+	}
+}
+
+func whereFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(ctx context.Context, out io.Writer, input Input) error {
+		pokemonName := input.Arg(0)
+		version := strings.ToLower(input.String("version"))
+
+		pokemon, err := lookupPokemon(ctx, client, trainer, pokemonName)
+		if err != nil {
+			return err
+		}
+
+		encounters, err := client.GetPokemonLocationAreas(ctx, pokemon.LocationAreaEncounters)
+		if err != nil {
+			return fmt.Errorf(
+				"unable to get the Pokemon's possible encounter areas: %w",
+				err,
+			)
+		}
+
+		groups := groupEncounters(encounters, version)
+
+		if len(groups) == 0 {
+			if version != "" {
+				fmt.Fprintf(out, "%s cannot be found in the wild in %s.\n", pokemonName, version)
+			} else {
+				fmt.Fprintf(out, "%s cannot be found in the wild.\n", pokemonName)
+			}
+
+			return nil
+		}
+
+		var visited []string
+		if input.Bool("visited") {
+			visited = trainer.VisitedLocationAreas()
+		}
+
+		var (
+			builder      strings.Builder
+			areas        []string
+			visitedAreas []string
+		)
+
+		for _, group := range slices.All(groups) {
+			builder.WriteString(group.version + ":\n")
+
+			for _, method := range slices.All(group.methods) {
+				builder.WriteString("  " + method.name + ":\n")
+
+				for _, area := range slices.All(method.areas) {
+					builder.WriteString("    - " + area.String())
+
+					if slices.Contains(visited, area.name) {
+						builder.WriteString(" (visited)")
+
+						if !slices.Contains(visitedAreas, area.name) {
+							visitedAreas = append(visitedAreas, area.name)
+						}
+					}
+
+					builder.WriteString("\n")
+
+					if !slices.Contains(areas, area.name) {
+						areas = append(areas, area.name)
+					}
+				}
+			}
+
+			writeEncounterTotals(&builder, group, visited)
+		}
+
+		areasFound := fmt.Sprintf("%d location areas", len(areas))
+		if len(areas) == 1 {
+			areasFound = "1 location area"
+		}
+
+		fmt.Fprintf(out, "%s can be found in %s:\n", pokemonName, areasFound)
+		fmt.Fprint(out, builder.String())
+
+		if input.Bool("visited") {
+			fmt.Fprintf(out, "You have visited %d of these location areas.\n", len(visitedAreas))
+		}
+
+		return nil
+	}
+}
+
+// encounterGroup is the encounters of a Pokemon in a version of the games.
+type encounterGroup struct {
+	version string
+	methods []encounterMethod
+	totals  []areaTotal
+}
+
+// areaTotal is the total chance of encountering a Pokemon in a location area
+// with any method in a version of the games, as given by the PokeAPI.
+type areaTotal struct {
+	name   string
+	chance int
+}
+
+// encounterMethod is the location areas where a Pokemon can be encountered with a method,
+// such as walking in tall grass or fishing with a rod.
+type encounterMethod struct {
+	name  string
+	areas []areaEncounter
+}
+
+// areaEncounter is the range of levels and the chance of encountering
+// a Pokemon in a location area.
+type areaEncounter struct {
+	name     string
+	minLevel int
+	maxLevel int
+	chance   int
+}
+
+func (a areaEncounter) String() string {
+	levels := fmt.Sprintf("level %d", a.minLevel)
+	if a.minLevel != a.maxLevel {
+		levels = fmt.Sprintf("levels %d-%d", a.minLevel, a.maxLevel)
+	}
+
+	return fmt.Sprintf("%s (%s, %d%% chance)", a.name, levels, a.chance)
+}
+
+// groupEncounters groups the encounters by version and method in the order
+// that they are listed by the PokeAPI. The encounter details for the same
+// location area and method are combined by adding up the chances of their
+// encounter slots. The PokeAPI's maximum chance for each location area is kept
+// as the total for all the methods in the version. Only the encounters
+// in the given version are grouped unless the version is empty.
+func groupEncounters(encounters []pokeapi.LocationAreaEncounter, version string) []encounterGroup {
+	var groups []encounterGroup
+
+	for _, encounter := range slices.All(encounters) {
+		for _, versionDetails := range slices.All(encounter.VersionDetails) {
+			if version != "" && versionDetails.Version.Name != version {
+				continue
+			}
+
+			groupInd := slices.IndexFunc(groups, func(group encounterGroup) bool {
+				return group.version == versionDetails.Version.Name
+			})
+			if groupInd < 0 {
+				groups = append(groups, encounterGroup{version: versionDetails.Version.Name, methods: nil, totals: nil})
+				groupInd = len(groups) - 1
+			}
+
+			group := &groups[groupInd]
+			group.totals = append(group.totals, areaTotal{name: encounter.LocationArea.Name, chance: versionDetails.MaxChance})
+
+			for _, details := range slices.All(versionDetails.EncounterDetails) {
+				methodInd := slices.IndexFunc(group.methods, func(method encounterMethod) bool {
+					return method.name == details.Method.Name
+				})
+				if methodInd < 0 {
+					group.methods = append(group.methods, encounterMethod{name: details.Method.Name, areas: nil})
+					methodInd = len(group.methods) - 1
+				}
+
+				method := &group.methods[methodInd]
+
+				areaInd := slices.IndexFunc(method.areas, func(area areaEncounter) bool {
+					return area.name == encounter.LocationArea.Name
+				})
+				if areaInd < 0 {
+					method.areas = append(method.areas, areaEncounter{
+						name:     encounter.LocationArea.Name,
+						minLevel: details.MinLevel,
+						maxLevel: details.MaxLevel,
+						chance:   0,
+					})
+					areaInd = len(method.areas) - 1
+				}
+
+				area := &method.areas[areaInd]
+				area.minLevel = min(area.minLevel, details.MinLevel)
+				area.maxLevel = max(area.maxLevel, details.MaxLevel)
+				area.chance = min(100, area.chance+details.Chance)
+			}
+		}
+	}
+
+	return groups
+}
+
+// writeEncounterTotals writes the total chance for the version of each
+// location area where the Pokemon can be encountered with more than one method.
+func writeEncounterTotals(builder *strings.Builder, group encounterGroup, visited []string) {
+	header := false
+
+	for _, total := range slices.All(group.totals) {
+		methods := 0
+
+		for _, method := range slices.All(group.methods) {
+			if slices.ContainsFunc(method.areas, func(area areaEncounter) bool { return area.name == total.name }) {
+				methods++
+			}
+		}
+
+		if methods < 2 {
+			continue
+		}
+
+		if !header {
+			builder.WriteString("  all methods:\n")
+
+			header = true
+		}
+
+		fmt.Fprintf(builder, "    - %s (%d%% chance)", total.name, total.chance)
+
+		if slices.Contains(visited, total.name) {
+			builder.WriteString(" (visited)")
+		}
+
+		builder.WriteString("\n")
+	}
+}
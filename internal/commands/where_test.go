@@ -0,0 +1,169 @@
+package commands_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/commands"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func TestWhere(t *testing.T) {
+	server := pokeapitest.NewServer(t)
+	server.HandleJSON(
+		"/api/v2/pokemon/pikachu/",
+		`{"id": 25, "name": "pikachu", "location_area_encounters": "`+pokeapitest.BaseURLPlaceholder+`/api/v2/pokemon/25/encounters"}`,
+	)
+	server.HandleJSON(
+		"/api/v2/pokemon/25/encounters",
+		`[
+			{
+				"location_area": {"name": "viridian-forest-area"},
+				"version_details": [
+					{
+						"version": {"name": "red"},
+						"max_chance": 8,
+						"encounter_details": [
+							{"min_level": 3, "max_level": 3, "chance": 5, "method": {"name": "walk"}},
+							{"min_level": 5, "max_level": 5, "chance": 5, "method": {"name": "walk"}}
+						]
+					},
+					{
+						"version": {"name": "yellow"},
+						"max_chance": 50,
+						"encounter_details": [{"min_level": 3, "max_level": 5, "chance": 50, "method": {"name": "walk"}}]
+					}
+				]
+			},
+			{
+				"location_area": {"name": "kanto-route-2-south-towards-viridian-city"},
+				"version_details": [
+					{
+						"version": {"name": "red"},
+						"max_chance": 10,
+						"encounter_details": [{"min_level": 4, "max_level": 4, "chance": 10, "method": {"name": "gift"}}]
+					}
+				]
+			}
+		]`,
+	)
+
+	server.HandleJSON(
+		"/api/v2/pokemon/lumineon/",
+		`{"id": 457, "name": "lumineon", "location_area_encounters": "`+pokeapitest.BaseURLPlaceholder+`/api/v2/pokemon/457/encounters"}`,
+	)
+	server.HandleJSON(
+		"/api/v2/pokemon/457/encounters",
+		`[
+			{
+				"location_area": {"name": "canalave-city-area"},
+				"version_details": [
+					{
+						"version": {"name": "diamond"},
+						"max_chance": 45,
+						"encounter_details": [
+							{"min_level": 20, "max_level": 25, "chance": 1, "method": {"name": "surf"}},
+							{"min_level": 25, "max_level": 30, "chance": 4, "method": {"name": "surf"}},
+							{"min_level": 30, "max_level": 35, "chance": 15, "method": {"name": "super-rod"}},
+							{"min_level": 35, "max_level": 40, "chance": 25, "method": {"name": "super-rod"}}
+						]
+					}
+				]
+			},
+			{
+				"location_area": {"name": "sunyshore-city-area"},
+				"version_details": [
+					{
+						"version": {"name": "diamond"},
+						"max_chance": 35,
+						"encounter_details": [
+							{"min_level": 20, "max_level": 30, "chance": 30, "method": {"name": "surf"}},
+							{"min_level": 30, "max_level": 40, "chance": 5, "method": {"name": "surf"}}
+						]
+					}
+				]
+			}
+		]`,
+	)
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	trainer := poketrainer.NewTrainer()
+
+	if err := trainer.UpdateCurrentLocationArea(pokeapi.LocationArea{Name: "viridian-forest-area"}); err != nil {
+		t.Fatalf("Unable to update the trainer's location: %v", err)
+	}
+
+	registry := commands.NewRegistry()
+	if err := registry.Register(commands.WhereCommand(client, trainer)); err != nil {
+		t.Fatalf("Unable to register the where command: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "All versions",
+			args: []string{"where", "--visited", "Pikachu"},
+			want: "pikachu can be found in 2 location areas:\n" +
+				"red:\n" +
+				"  walk:\n" +
+				"    - viridian-forest-area (levels 3-5, 10% chance) (visited)\n" +
+				"  gift:\n" +
+				"    - kanto-route-2-south-towards-viridian-city (level 4, 10% chance)\n" +
+				"yellow:\n" +
+				"  walk:\n" +
+				"    - viridian-forest-area (levels 3-5, 50% chance) (visited)\n" +
+				"You have visited 1 of these location areas.\n",
+		},
+		{
+			name: "One version",
+			args: []string{"where", "--version", "yellow", "pikachu"},
+			want: "pikachu can be found in 1 location area:\n" +
+				"yellow:\n" +
+				"  walk:\n" +
+				"    - viridian-forest-area (levels 3-5, 50% chance)\n",
+		},
+		{
+			name: "More than one method",
+			args: []string{"where", "lumineon"},
+			want: "lumineon can be found in 2 location areas:\n" +
+				"diamond:\n" +
+				"  surf:\n" +
+				"    - canalave-city-area (levels 20-30, 5% chance)\n" +
+				"    - sunyshore-city-area (levels 20-40, 35% chance)\n" +
+				"  super-rod:\n" +
+				"    - canalave-city-area (levels 30-40, 40% chance)\n" +
+				"  all methods:\n" +
+				"    - canalave-city-area (45% chance)\n",
+		},
+		{
+			name: "Not in the version",
+			args: []string{"where", "--version", "gold", "pikachu"},
+			want: "pikachu cannot be found in the wild in gold.\n",
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			var output strings.Builder
+
+			if err := registry.Run(context.Background(), &output, testcase.args); err != nil {
+				t.Fatalf("Unable to run %v: %v", testcase.args, err)
+			}
+
+			if got := output.String(); got != testcase.want {
+				t.Errorf("Unexpected output:\nwant:\n%s\ngot:\n%s", testcase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Package fuzzy finds the closest matches for misspelled names.
+package fuzzy
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Distance returns the Levenshtein edit distance between the two strings.
+func Distance(source, target string) int {
+	sourceRunes, targetRunes := []rune(source), []rune(target)
+
+	previous := make([]int, len(targetRunes)+1)
+	current := make([]int, len(targetRunes)+1)
+
+	for ind := range previous {
+		previous[ind] = ind
+	}
+
+	for i := 1; i <= len(sourceRunes); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(targetRunes); j++ {
+			cost := 1
+			if sourceRunes[i-1] == targetRunes[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(
+				previous[j]+1,
+				current[j-1]+1,
+				previous[j-1]+cost,
+			)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(targetRunes)]
+}
+
+// Suggest returns up to limit candidates that closely match the name, closest first.
+// A candidate matches if it starts with the name, or if it is within a small
+// edit distance of the name relative to its length.
+func Suggest(name string, candidates []string, limit int) []string {
+	type match struct {
+		candidate string
+		prefix    bool
+		distance  int
+	}
+
+	maxDistance := max(2, len([]rune(name))/3)
+	matches := make([]match, 0)
+
+	for _, candidate := range slices.All(candidates) {
+		if candidate == name {
+			continue
+		}
+
+		prefix := name != "" && strings.HasPrefix(candidate, name)
+		distance := Distance(name, candidate)
+
+		if prefix || distance <= maxDistance {
+			matches = append(matches, match{candidate: candidate, prefix: prefix, distance: distance})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b match) int {
+		if a.distance != b.distance {
+			return cmp.Compare(a.distance, b.distance)
+		}
+
+		if a.prefix != b.prefix {
+			if a.prefix {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a.candidate, b.candidate)
+	})
+
+	suggestions := make([]string, 0, min(limit, len(matches)))
+
+	for _, m := range slices.All(matches[:min(limit, len(matches))]) {
+		suggestions = append(suggestions, m.candidate)
+	}
+
+	return suggestions
+}
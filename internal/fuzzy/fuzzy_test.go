@@ -0,0 +1,55 @@
+package fuzzy_test
+
+import (
+	"slices"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/fuzzy"
+)
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		source string
+		target string
+		want   int
+	}{
+		{source: "pikachu", target: "pikachu", want: 0},
+		{source: "pikachuu", target: "pikachu", want: 1},
+		{source: "pikahcu", target: "pikachu", want: 2},
+		{source: "", target: "eevee", want: 5},
+		{source: "kitten", target: "sitting", want: 3},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		if got := fuzzy.Distance(testcase.source, testcase.target); got != testcase.want {
+			t.Errorf(
+				"Unexpected distance between %q and %q: want %d, got %d",
+				testcase.source,
+				testcase.target,
+				testcase.want,
+				got,
+			)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"pikachu", "pichu", "raichu", "charmander", "charmeleon", "charizard"}
+
+	cases := []struct {
+		name string
+		want []string
+	}{
+		{name: "pikachuu", want: []string{"pikachu"}},
+		{name: "charm", want: []string{"charmander", "charmeleon"}},
+		{name: "bulbasaur", want: []string{}},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		got := fuzzy.Suggest(testcase.name, candidates, 3)
+
+		if !slices.Equal(got, testcase.want) {
+			t.Errorf("Unexpected suggestions for %q: want %v, got %v", testcase.name, testcase.want, got)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Package pokeapitest provides a stub PokeAPI server for testing.
+package pokeapitest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// BaseURLPlaceholder is replaced with the server's base URL in the
+// bodies of the JSON responses.
+const BaseURLPlaceholder = "{{baseURL}}"
+
+// Server is a stub PokeAPI server that counts the requests it receives.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	mux      *http.ServeMux
+	requests map[string]int
+}
+
+// NewServer starts a new stub server which is closed when the test finishes.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	server := Server{
+		Server:   nil,
+		mu:       sync.Mutex{},
+		mux:      http.NewServeMux(),
+		requests: make(map[string]int),
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+
+	tb.Cleanup(server.Close)
+
+	return &server
+}
+
+// Handle registers the handler for the path.
+func (s *Server) Handle(path string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(path, handler)
+}
+
+// HandleJSON registers a handler that responds to requests for the path with the JSON body.
+func (s *Server) HandleJSON(path, body string) {
+	s.Handle(path, func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(strings.ReplaceAll(body, BaseURLPlaceholder, s.URL)))
+	})
+}
+
+// Requests returns the number of requests that the server received for the path.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[path]
+}
+
+// TotalRequests returns the total number of requests that the server received.
+func (s *Server) TotalRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := 0
+
+	for _, count := range s.requests {
+		total += count
+	}
+
+	return total
+}
+
+func (s *Server) serveHTTP(writer http.ResponseWriter, request *http.Request) {
+	s.mu.Lock()
+	s.requests[request.URL.Path]++
+	s.mu.Unlock()
+
+	s.mux.ServeHTTP(writer, request)
+}
@@ -1,29 +1,93 @@
 package pokecache
 
 import (
-	"maps"
+	"container/list"
+	"slices"
 	"sync"
 	"time"
 )
 
+// Cache is an in-memory cache of byte values. When the cache is configured with
+// a maximum size or number of entries, the least recently used entries are evicted
+// to make room for new ones.
 type Cache struct {
-	stopChan chan struct{}
-	mu       *sync.Mutex
-	entries  map[string]cacheEntry
-	interval time.Duration
+	stopChan   chan struct{}
+	stopOnce   *sync.Once
+	mu         *sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List
+	interval   time.Duration
+	maxBytes   int
+	maxEntries int
+	bytes      int
+	stats      Stats
+}
+
+// Entry is a value stored in the cache along with the validators that can be
+// used to check whether or not a stale value is still up to date.
+type Entry struct {
+	Value        []byte
+	ETag         string
+	LastModified string
+
+	// ExpiresAt is when the entry becomes stale. If it is not set then the
+	// entry becomes stale after the cache's interval.
+	ExpiresAt time.Time
+}
+
+// Stats is a snapshot of the cache's usage.
+type Stats struct {
+	Hits        int
+	Misses      int
+	Evictions   int
+	Expirations int
+	Entries     int
+	Bytes       int
+	MaxEntries  int
+	MaxBytes    int
+}
+
+// Option is a functional option for configuring the cache.
+type Option func(*Cache)
+
+// WithMaxBytes limits the total size of the keys and values stored in the cache.
+// A limit of zero means that the size is unlimited.
+func WithMaxBytes(maxBytes int) Option {
+	return func(c *Cache) {
+		c.maxBytes = maxBytes
+	}
+}
+
+// WithMaxEntries limits the number of entries stored in the cache.
+// A limit of zero means that the number of entries is unlimited.
+func WithMaxEntries(maxEntries int) Option {
+	return func(c *Cache) {
+		c.maxEntries = maxEntries
+	}
 }
 
 type cacheEntry struct {
+	key       string
 	createdAt time.Time
-	val       []byte
+	entry     Entry
 }
 
-func NewCache(interval time.Duration) *Cache {
+func NewCache(interval time.Duration, options ...Option) *Cache {
 	cache := Cache{
-		stopChan: make(chan struct{}),
-		mu:       &sync.Mutex{},
-		entries:  make(map[string]cacheEntry),
-		interval: interval,
+		stopChan:   make(chan struct{}),
+		stopOnce:   &sync.Once{},
+		mu:         &sync.Mutex{},
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		interval:   interval,
+		maxBytes:   0,
+		maxEntries: 0,
+		bytes:      0,
+		stats:      Stats{},
+	}
+
+	for _, option := range options {
+		option(&cache)
 	}
 
 	go cache.readLoop()
@@ -32,54 +96,251 @@ func NewCache(interval time.Duration) *Cache {
 }
 
 func (c *Cache) Add(key string, val []byte) {
+	c.AddEntry(key, Entry{Value: val})
+}
+
+// AddWithTTL adds the value to the cache which becomes stale after the TTL
+// instead of after the cache's interval.
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	c.AddEntry(key, Entry{Value: val, ExpiresAt: time.Now().Add(ttl)})
+}
+
+// AddEntry adds the entry to the cache, evicting the least recently used
+// entries if the cache is over its limits.
+func (c *Cache) AddEntry(key string, entry Entry) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	entry := cacheEntry{
+	if element, exists := c.entries[key]; exists {
+		c.remove(element)
+	}
+
+	value := cacheEntry{
+		key:       key,
 		createdAt: time.Now(),
-		val:       val,
+		entry:     entry,
+	}
+
+	// An entry that can never fit in the cache is not stored.
+	if c.maxBytes > 0 && value.size() > c.maxBytes {
+		return
 	}
 
-	c.entries[key] = entry
+	c.entries[key] = c.lru.PushFront(value)
+	c.bytes += value.size()
+
+	c.evict()
 }
 
+// Get returns the value stored in the cache if it exists and is not stale.
 func (c *Cache) Get(key string) ([]byte, bool) {
+	entry, fresh, exists := c.Lookup(key)
+	if !exists || !fresh {
+		return nil, false
+	}
+
+	return entry.Value, true
+}
+
+// Lookup returns the entry stored in the cache even if it is stale, along with
+// whether or not it is still fresh. Stale entries are only kept in the cache if
+// they can be revalidated.
+func (c *Cache) Lookup(key string) (Entry, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		c.stats.Misses++
+
+		return Entry{}, false, false
+	}
+
+	c.lru.MoveToFront(element)
+
+	value := element.Value.(cacheEntry)
+
+	fresh := !c.expired(value, time.Now())
+	if fresh {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+
+	return value.entry, fresh, true
+}
+
+// Peek is like Lookup except that it neither marks the entry as recently
+// used nor counts towards the cache's statistics.
+func (c *Cache) Peek(key string) (Entry, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return Entry{}, false, false
+	}
+
+	value := element.Value.(cacheEntry)
+
+	return value.entry, !c.expired(value, time.Now()), true
+}
+
+// Refresh marks a stale entry as fresh again after it has been revalidated.
+// It returns false if the entry is no longer in the cache.
+func (c *Cache) Refresh(key string, expiresAt time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return false
+	}
+
+	value := element.Value.(cacheEntry)
+	value.createdAt = time.Now()
+	value.entry.ExpiresAt = expiresAt
+
+	element.Value = value
+
+	c.lru.MoveToFront(element)
+
+	return true
+}
+
+// Stats returns a snapshot of the cache's usage.
+func (c *Cache) Stats() Stats {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	value, exist := c.entries[key]
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	stats.Bytes = c.bytes
+	stats.MaxEntries = c.maxEntries
+	stats.MaxBytes = c.maxBytes
 
-	return value.val, exist
+	return stats
 }
 
-// func (c *Cache) Stop() {
-// 	c.stopChan <- struct{}{}
-// }
+// Delete removes the entry from the cache. It returns false if the
+// entry was not in the cache.
+func (c *Cache) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return false
+	}
+
+	c.remove(element)
+
+	return true
+}
+
+// Clear removes all the entries from the cache. The cache's statistics are kept.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	c.bytes = 0
+}
+
+// Keys returns the sorted keys of all the entries in the cache, including the stale ones.
+func (c *Cache) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.entries))
+
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// Stop stops the goroutine that periodically removes the stale entries from
+// the cache. The cache can still be used after it is stopped. It is safe to
+// call Stop more than once.
+func (c *Cache) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopChan)
+	})
+}
+
+// Close stops the cache. It implements io.Closer and always returns nil.
+func (c *Cache) Close() error {
+	c.Stop()
+
+	return nil
+}
 
 func (c *Cache) readLoop() {
 	ticker := time.NewTicker(c.interval)
 	defer ticker.Stop()
 
-readloop:
 	for {
 		select {
 		case <-c.stopChan:
-			break readloop
+			return
 		case <-ticker.C:
 			c.cleanupEntries()
 		}
 	}
 }
 
+// cleanupEntries removes the stale entries that cannot be revalidated.
 func (c *Cache) cleanupEntries() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	expiredTime := time.Now().Add(-c.interval)
+	now := time.Now()
 
-	for key := range maps.All(c.entries) {
-		if c.entries[key].createdAt.Before(expiredTime) {
-			delete(c.entries, key)
+	for _, element := range c.entries {
+		value := element.Value.(cacheEntry)
+
+		if c.expired(value, now) && !value.entry.revalidatable() {
+			c.remove(element)
+			c.stats.Expirations++
 		}
 	}
 }
+
+// evict removes the least recently used entries until the cache is within its limits.
+func (c *Cache) evict() {
+	for c.lru.Len() > 0 &&
+		((c.maxEntries > 0 && c.lru.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes)) {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) remove(element *list.Element) {
+	value := element.Value.(cacheEntry)
+
+	c.lru.Remove(element)
+	delete(c.entries, value.key)
+	c.bytes -= value.size()
+}
+
+func (c *Cache) expired(value cacheEntry, now time.Time) bool {
+	if !value.entry.ExpiresAt.IsZero() {
+		return !now.Before(value.entry.ExpiresAt)
+	}
+
+	return value.createdAt.Before(now.Add(-c.interval))
+}
+
+func (e Entry) revalidatable() bool {
+	return e.ETag != "" || e.LastModified != ""
+}
+
+// size returns the number of bytes that the entry takes up in the cache.
+func (v cacheEntry) size() int {
+	return len(v.key) + len(v.entry.Value) + len(v.entry.ETag) + len(v.entry.LastModified)
+}
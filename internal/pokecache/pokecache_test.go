@@ -6,11 +6,11 @@ import (
 	"testing"
 	"time"
 
-	"codeflow.dananglin.me.uk/apollo/pokedex/internal/pokecache"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokecache"
 )
 
 const (
-	keyNotFoundFormat = "The key %q was not found after adding it to the cache"
+	keyNotFoundFormat          = "The key %q was not found after adding it to the cache"
 	keyFoundAfterCleanupFormat = "The key %q was found after cache cleanup"
 )
 
@@ -32,6 +32,7 @@ func TestCacheAddGet(t *testing.T) {
 	interval := 1 * time.Minute
 
 	cache := pokecache.NewCache(interval)
+	defer cache.Stop()
 
 	testFunc := func(key string, value []byte) func(*testing.T) {
 		return func(t *testing.T) {
@@ -66,6 +67,7 @@ func TestReadLoop(t *testing.T) {
 	value := []byte(`{"version": "v1.0.0", "key": "value"}`)
 
 	cache := pokecache.NewCache(baseTime)
+	defer cache.Stop()
 
 	cache.Add(key, value)
 
@@ -79,3 +81,193 @@ func TestReadLoop(t *testing.T) {
 		t.Errorf(keyFoundAfterCleanupFormat, key)
 	}
 }
+
+func TestRevalidatableEntriesSurviveCleanup(t *testing.T) {
+	const (
+		baseTime = 5 * time.Millisecond
+		waitTime = 10 * baseTime
+	)
+
+	key := "https://example.org/api/v1/path"
+	value := []byte(`{"version": "v1.0.0", "key": "value"}`)
+
+	cache := pokecache.NewCache(baseTime)
+	defer cache.Stop()
+
+	cache.AddEntry(key, pokecache.Entry{Value: value, ETag: `"v1"`})
+
+	time.Sleep(waitTime)
+
+	if _, exists := cache.Get(key); exists {
+		t.Errorf("The stale value for the key %q was returned from the cache", key)
+	}
+
+	entry, fresh, exists := cache.Lookup(key)
+	if !exists {
+		t.Fatalf("The revalidatable entry for the key %q was removed during cleanup", key)
+	}
+
+	if fresh {
+		t.Errorf("The entry for the key %q is fresh after it has expired", key)
+	}
+
+	if entry.ETag != `"v1"` {
+		t.Errorf("Unexpected ETag: want %q, got %q", `"v1"`, entry.ETag)
+	}
+
+	if !cache.Refresh(key, time.Now().Add(time.Minute)) {
+		t.Fatalf("Unable to refresh the entry for the key %q", key)
+	}
+
+	if _, exists := cache.Get(key); !exists {
+		t.Errorf("The value for the key %q was not returned after it was refreshed", key)
+	}
+}
+
+func TestLRUEviction(t *testing.T) {
+	cache := pokecache.NewCache(time.Minute, pokecache.WithMaxEntries(2))
+	defer cache.Stop()
+
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+
+	// Use "a" so that "b" becomes the least recently used entry.
+	if _, exists := cache.Get("a"); !exists {
+		t.Fatalf(keyNotFoundFormat, "a")
+	}
+
+	cache.Add("c", []byte("3"))
+
+	if _, exists := cache.Get("b"); exists {
+		t.Error("The least recently used key \"b\" was not evicted from the cache")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if _, exists := cache.Get(key); !exists {
+			t.Errorf(keyNotFoundFormat, key)
+		}
+	}
+
+	stats := cache.Stats()
+
+	want := pokecache.Stats{
+		Hits:        3,
+		Misses:      1,
+		Evictions:   1,
+		Expirations: 0,
+		Entries:     2,
+		Bytes:       4,
+		MaxEntries:  2,
+		MaxBytes:    0,
+	}
+
+	if stats != want {
+		t.Errorf("Unexpected cache statistics: want %+v, got %+v", want, stats)
+	}
+}
+
+func TestMaxBytesEviction(t *testing.T) {
+	cache := pokecache.NewCache(time.Minute, pokecache.WithMaxBytes(10))
+	defer cache.Stop()
+
+	cache.Add("a", []byte("1234"))
+	cache.Add("b", []byte("1234"))
+	cache.Add("c", []byte("1234"))
+
+	if _, exists := cache.Get("a"); exists {
+		t.Error("The key \"a\" was not evicted after the cache went over its size limit")
+	}
+
+	if got := cache.Stats().Bytes; got > 10 {
+		t.Errorf("The size of the cache is over its limit: want <= 10, got %d", got)
+	}
+
+	cache.Add("huge", []byte("this value is larger than the cache"))
+
+	if _, exists := cache.Get("huge"); exists {
+		t.Error("A value larger than the cache was stored in the cache")
+	}
+}
+
+func TestPerEntryTTL(t *testing.T) {
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Stop()
+
+	cache.AddWithTTL("short", []byte("value"), 5*time.Millisecond)
+	cache.Add("long", []byte("value"))
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, exists := cache.Get("short"); exists {
+		t.Error("The key \"short\" was returned from the cache after its TTL")
+	}
+
+	if _, exists := cache.Get("long"); !exists {
+		t.Errorf(keyNotFoundFormat, "long")
+	}
+}
+
+func TestDeleteClearKeys(t *testing.T) {
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Stop()
+
+	for _, key := range []string{"charmander", "bulbasaur", "squirtle"} {
+		cache.Add(key, []byte(key))
+	}
+
+	if got, want := cache.Keys(), []string{"bulbasaur", "charmander", "squirtle"}; !slices.Equal(got, want) {
+		t.Errorf("Unexpected keys: want %v, got %v", want, got)
+	}
+
+	if !cache.Delete("charmander") {
+		t.Error("The key \"charmander\" was not deleted from the cache")
+	}
+
+	if cache.Delete("charmander") {
+		t.Error("The key \"charmander\" was deleted from the cache twice")
+	}
+
+	if _, exists := cache.Get("charmander"); exists {
+		t.Error("The key \"charmander\" was found after it was deleted")
+	}
+
+	cache.Clear()
+
+	if keys := cache.Keys(); len(keys) != 0 {
+		t.Errorf("Unexpected keys after the cache was cleared: %v", keys)
+	}
+
+	if stats := cache.Stats(); stats.Bytes != 0 || stats.Entries != 0 {
+		t.Errorf("Unexpected size after the cache was cleared: %d entries, %d bytes", stats.Entries, stats.Bytes)
+	}
+
+	cache.Add("pikachu", []byte("pikachu"))
+
+	if _, exists := cache.Get("pikachu"); !exists {
+		t.Errorf(keyNotFoundFormat, "pikachu")
+	}
+}
+
+func TestStop(t *testing.T) {
+	const (
+		baseTime = 5 * time.Millisecond
+		waitTime = 10 * baseTime
+	)
+
+	cache := pokecache.NewCache(baseTime)
+
+	cache.Stop()
+	cache.Stop()
+
+	if err := cache.Close(); err != nil {
+		t.Fatalf("Unexpected error closing a stopped cache: %v", err)
+	}
+
+	cache.AddEntry("pikachu", pokecache.Entry{Value: []byte("pikachu")})
+
+	time.Sleep(waitTime)
+
+	if _, _, exists := cache.Lookup("pikachu"); !exists {
+		t.Error("The stale entry was removed after the cache was stopped")
+	}
+}
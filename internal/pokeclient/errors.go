@@ -0,0 +1,69 @@
+package pokeclient
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotFoundError is returned when the requested resource does not exist.
+type NotFoundError struct {
+	URL string
+}
+
+func (e *NotFoundError) Error() string {
+	return "the resource at " + e.URL + " was not found"
+}
+
+// RateLimitedError is returned when the server is rate limiting the client.
+// RetryAfter is how long the server asked the client to wait before trying
+// again, or zero if the server did not say.
+type RateLimitedError struct {
+	URL        string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("too many requests sent to %s; retry after %s", e.URL, e.RetryAfter)
+	}
+
+	return "too many requests sent to " + e.URL
+}
+
+// ServerError is returned when the server fails to handle the request.
+type ServerError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("the server failed to handle the request to %s: %s", e.URL, e.Status)
+}
+
+// StatusError is returned when the server responds with any other
+// unsuccessful status.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("received a bad status from %s: %s", e.URL, e.Status)
+}
+
+// NetworkError is returned when the request could not be sent to the server
+// or the response could not be read from it.
+type NetworkError struct {
+	URL string
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,80 @@
+package pokeclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// flightGroup deduplicates concurrent fetches of the same URL so that only
+// one request is sent to the server and its result is shared with every caller.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+type flight struct {
+	done   chan struct{}
+	data   []byte
+	source source
+	err    error
+
+	// abandoned is true if the context of the caller that started the fetch
+	// was cancelled before the fetch finished.
+	abandoned bool
+}
+
+// do calls fetch for the URL unless a fetch for the same URL is already in
+// flight, in which case it waits for that fetch to finish and returns its result.
+// A caller that is waiting for another caller's fetch stops waiting as soon as
+// its context is cancelled. The fetch runs with the context of the caller that
+// started it, so if that caller abandons the fetch then a waiting caller whose
+// context is still live tries again with its own fetch.
+func (g *flightGroup) do(ctx context.Context, url string, fetch func() ([]byte, source, error)) ([]byte, source, error) {
+	for {
+		g.mu.Lock()
+
+		if g.flights == nil {
+			g.flights = make(map[string]*flight)
+		}
+
+		if inFlight, ok := g.flights[url]; ok {
+			g.mu.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return nil, "", fmt.Errorf("stopped waiting for the request: %w", ctx.Err())
+			case <-inFlight.done:
+			}
+
+			if inFlight.abandoned && ctx.Err() == nil {
+				continue
+			}
+
+			return inFlight.data, inFlight.source, inFlight.err
+		}
+
+		newFlight := &flight{
+			done:      make(chan struct{}),
+			data:      nil,
+			source:    "",
+			err:       nil,
+			abandoned: false,
+		}
+
+		g.flights[url] = newFlight
+
+		g.mu.Unlock()
+
+		newFlight.data, newFlight.source, newFlight.err = fetch()
+		newFlight.abandoned = newFlight.err != nil && ctx.Err() != nil
+
+		g.mu.Lock()
+		delete(g.flights, url)
+		g.mu.Unlock()
+
+		close(newFlight.done)
+
+		return newFlight.data, newFlight.source, newFlight.err
+	}
+}
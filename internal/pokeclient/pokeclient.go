@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
@@ -13,190 +17,314 @@ import (
 )
 
 const (
-	baseURL string = "https://pokeapi.co"
-
-	LocationAreaPath = baseURL + "/api/v2/location-area"
-	PokemonPath      = baseURL + "/api/v2/pokemon"
+	defaultBaseURL               string = "https://pokeapi.co"
+	defaultMaxConcurrentRequests int    = 4
 )
 
 type Client struct {
-	httpClient http.Client
-	cache      *pokecache.Cache
-	timeout    time.Duration
+	httpClient            http.Client
+	cache                 *pokecache.Cache
+	timeout               time.Duration
+	baseURL               string
+	retryPolicy           RetryPolicy
+	cacheOptions          []pokecache.Option
+	maxConcurrentRequests int
+	requestSlots          chan struct{}
+	flights               *flightGroup
+	prefetches            *sync.WaitGroup
+	prefetchMu            *sync.Mutex
+	logger                *slog.Logger
+	spriteCacheDir        string
+
+	// ctx is cancelled when the client is closed to stop
+	// the prefetches running in the background. It is cancelled
+	// while holding prefetchMu so that no prefetch can start after it.
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-func NewClient(cacheCleanupInterval, timeout time.Duration) *Client {
-	cache := pokecache.NewCache(cacheCleanupInterval)
+// Option is a functional option for configuring the client.
+type Option func(*Client)
 
-	client := Client{
-		httpClient: http.Client{},
-		cache:      cache,
-		timeout:    timeout,
+// WithBaseURL sets the base URL of the PokeAPI server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
 	}
-
-	return &client
 }
 
-func (c *Client) GetNamedAPIResourceList(url string) (pokeapi.NamedAPIResourceList, error) {
-	var list pokeapi.NamedAPIResourceList
-
-	data, exists := c.cache.Get(url)
-	if exists {
-		fmt.Println("(using data from cache)")
+// WithCacheOptions sets the options for the client's cache.
+func WithCacheOptions(cacheOptions ...pokecache.Option) Option {
+	return func(c *Client) {
+		c.cacheOptions = append(c.cacheOptions, cacheOptions...)
+	}
+}
 
-		if err := decodeJSON(data, &list); err != nil {
-			return pokeapi.NamedAPIResourceList{}, fmt.Errorf("unable to decode the data from the cache: %w", err)
-		}
+// WithMaxConcurrentRequests limits the number of requests that the client
+// sends to the server at the same time.
+func WithMaxConcurrentRequests(maxConcurrentRequests int) Option {
+	return func(c *Client) {
+		c.maxConcurrentRequests = max(maxConcurrentRequests, 1)
+	}
+}
 
-		return list, nil
+// WithLogger sets the logger for the client's diagnostics such as the requests
+// sent to the server, their timings and the data served from the cache.
+// By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
 	}
+}
 
-	data, err := c.sendRequest(url)
-	if err != nil {
-		return pokeapi.NamedAPIResourceList{}, fmt.Errorf(
-			"received an error after sending the request to the server: %w",
-			err,
-		)
+func NewClient(cacheCleanupInterval, timeout time.Duration, options ...Option) *Client {
+	client := Client{
+		httpClient:            http.Client{},
+		cache:                 nil,
+		timeout:               timeout,
+		baseURL:               defaultBaseURL,
+		retryPolicy:           DefaultRetryPolicy(),
+		cacheOptions:          nil,
+		maxConcurrentRequests: defaultMaxConcurrentRequests,
+		requestSlots:          nil,
+		flights:               &flightGroup{},
+		prefetches:            &sync.WaitGroup{},
+		prefetchMu:            &sync.Mutex{},
+		logger:                slog.New(slog.NewTextHandler(io.Discard, nil)),
+		spriteCacheDir:        "",
+		ctx:                   nil,
+		cancel:                nil,
 	}
 
-	if err := decodeJSON(data, &list); err != nil {
-		return pokeapi.NamedAPIResourceList{}, fmt.Errorf("unable to decode the data from the server: %w", err)
+	for _, option := range options {
+		option(&client)
 	}
 
-	c.cache.Add(url, data)
+	client.cache = pokecache.NewCache(cacheCleanupInterval, client.cacheOptions...)
+	client.requestSlots = make(chan struct{}, client.maxConcurrentRequests)
+	client.ctx, client.cancel = context.WithCancel(context.Background())
 
-	return list, nil
+	return &client
 }
 
-func (c *Client) GetLocationArea(location string) (pokeapi.LocationArea, error) {
-	var locationArea pokeapi.LocationArea
+// CacheStats returns a snapshot of the usage of the client's cache.
+func (c *Client) CacheStats() pokecache.Stats {
+	return c.cache.Stats()
+}
 
-	url := LocationAreaPath + "/" + location + "/"
+// CacheKeys returns the keys of all the entries in the client's cache.
+func (c *Client) CacheKeys() []string {
+	return c.cache.Keys()
+}
 
-	data, exists := c.cache.Get(url)
-	if exists {
-		fmt.Println("(using data from cache)")
+// ClearCache removes all the entries from the client's cache.
+func (c *Client) ClearCache() {
+	c.cache.Clear()
+}
 
-		if err := decodeJSON(data, &locationArea); err != nil {
-			return pokeapi.LocationArea{}, fmt.Errorf("unable to decode the data from the cache: %w", err)
-		}
+// PurgeCacheEntry removes the entry from the client's cache. It returns
+// false if the entry was not in the cache.
+func (c *Client) PurgeCacheEntry(key string) bool {
+	return c.cache.Delete(key)
+}
 
-		return locationArea, nil
-	}
+// Close cancels the prefetches running in the background, waits for them
+// to stop and then releases the resources used by the client.
+func (c *Client) Close() error {
+	c.prefetchMu.Lock()
+	c.cancel()
+	c.prefetchMu.Unlock()
 
-	data, err := c.sendRequest(url)
-	if err != nil {
-		return pokeapi.LocationArea{}, fmt.Errorf(
-			"received an error after sending the request to the server: %w",
-			err,
-		)
-	}
+	c.prefetches.Wait()
 
-	if err := decodeJSON(data, &locationArea); err != nil {
-		return pokeapi.LocationArea{}, fmt.Errorf("unable to decode the data from the server: %w", err)
-	}
+	return c.cache.Close()
+}
 
-	c.cache.Add(url, data)
+// ListURL returns the URL of the first page of the list of resources at the path.
+func (c *Client) ListURL(path string) string {
+	return c.baseURL + path
+}
 
-	return locationArea, nil
+func (c *Client) GetNamedAPIResourceList(ctx context.Context, url string) (pokeapi.NamedAPIResourceList, error) {
+	return GetURL[pokeapi.NamedAPIResourceList](ctx, c, url)
 }
 
-func (c *Client) GetPokemon(pokemonName string) (pokeapi.Pokemon, error) {
-	var pokemon pokeapi.Pokemon
+func (c *Client) GetLocationArea(ctx context.Context, location string) (pokeapi.LocationArea, error) {
+	return Get(ctx, c, LocationAreaResource, location)
+}
 
-	url := PokemonPath + "/" + pokemonName + "/"
+func (c *Client) GetPokemon(ctx context.Context, pokemonName string) (pokeapi.Pokemon, error) {
+	return Get(ctx, c, PokemonResource, pokemonName)
+}
 
-	data, exists := c.cache.Get(url)
-	if exists {
-		fmt.Println("(using data from cache)")
+func (c *Client) GetPokemonSpecies(ctx context.Context, speciesName string) (pokeapi.PokemonSpecies, error) {
+	return Get(ctx, c, PokemonSpeciesResource, speciesName)
+}
 
-		if err := decodeJSON(data, &pokemon); err != nil {
-			return pokeapi.Pokemon{}, fmt.Errorf("unable to decode the data from the cache: %w", err)
-		}
+func (c *Client) GetAbility(ctx context.Context, abilityName string) (pokeapi.Ability, error) {
+	return Get(ctx, c, AbilityResource, abilityName)
+}
 
-		return pokemon, nil
-	}
+func (c *Client) GetItem(ctx context.Context, itemName string) (pokeapi.Item, error) {
+	return Get(ctx, c, ItemResource, itemName)
+}
 
-	data, err := c.sendRequest(url)
-	if err != nil {
-		return pokeapi.Pokemon{}, fmt.Errorf(
-			"received an error after sending the request to the server: %w",
-			err,
-		)
-	}
+func (c *Client) GetBerry(ctx context.Context, berryName string) (pokeapi.Berry, error) {
+	return Get(ctx, c, BerryResource, berryName)
+}
 
-	if err := decodeJSON(data, &pokemon); err != nil {
-		return pokeapi.Pokemon{}, fmt.Errorf("unable to decode the data from the server: %w", err)
-	}
+func (c *Client) GetType(ctx context.Context, typeName string) (pokeapi.Type, error) {
+	return Get(ctx, c, TypeResource, typeName)
+}
 
-	c.cache.Add(url, data)
+func (c *Client) GetPokemonLocationAreas(ctx context.Context, url string) ([]pokeapi.LocationAreaEncounter, error) {
+	return GetURL[[]pokeapi.LocationAreaEncounter](ctx, c, url)
+}
 
-	return pokemon, nil
+// response is the data and caching metadata from a successful request.
+type response struct {
+	data         []byte
+	etag         string
+	lastModified string
+	expiresAt    time.Time
+	notModified  bool
 }
 
-func (c *Client) GetPokemonLocationAreas(url string) ([]pokeapi.LocationAreaEncounter, error) {
-	var locationAreaEncounters []pokeapi.LocationAreaEncounter
+// sendRequest sends a GET request to the URL, retrying according to the
+// client's retry policy. If a stale cache entry is given then the request is
+// made conditional on the entry's validators so that the server can respond with
+// 304 Not Modified instead of sending the data again. The request is
+// abandoned as soon as the context is cancelled.
+func (c *Client) sendRequest(ctx context.Context, url string, stale *pokecache.Entry) (response, error) {
+	var deadline time.Time
 
-	data, exists := c.cache.Get(url)
-	if exists {
-		fmt.Println("(using data from cache)")
+	if c.retryPolicy.Deadline > 0 {
+		var cancel context.CancelFunc
 
-		if err := decodeJSON(data, &locationAreaEncounters); err != nil {
-			return []pokeapi.LocationAreaEncounter{}, fmt.Errorf(
-				"unable to decode the data from the cache: %w",
-				err,
-			)
-		}
-	}
+		deadline = time.Now().Add(c.retryPolicy.Deadline)
+		ctx, cancel = context.WithDeadline(ctx, deadline)
 
-	data, err := c.sendRequest(url)
-	if err != nil {
-		return []pokeapi.LocationAreaEncounter{}, fmt.Errorf(
-			"received an error after sending the request to the server: %w",
-			err,
-		)
+		defer cancel()
 	}
 
-	if err := decodeJSON(data, &locationAreaEncounters); err != nil {
-		return []pokeapi.LocationAreaEncounter{}, fmt.Errorf("unable to decode the data from the server: %w", err)
-	}
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendRequestOnce(ctx, url, stale)
+		if err == nil {
+			return resp, nil
+		}
+
+		if ctx.Err() != nil {
+			return response{}, err
+		}
+
+		delay, retry := c.retryPolicy.retryDelay(err, attempt)
+		if !retry || (!deadline.IsZero() && time.Now().Add(delay).After(deadline)) {
+			return response{}, err
+		}
+
+		c.logger.Debug("Retrying the request", "url", url, "attempt", attempt, "delay", delay, "error", err)
+
+		timer := time.NewTimer(delay)
 
-	return locationAreaEncounters, nil
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return response{}, fmt.Errorf("the request was abandoned before it could be retried: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
 }
 
-func (c *Client) sendRequest(url string) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+func (c *Client) sendRequestOnce(ctx context.Context, url string, stale *pokecache.Entry) (response, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return []byte{}, fmt.Errorf("error creating the HTTP request: %w", err)
+		return response{}, fmt.Errorf("error creating the HTTP request: %w", err)
 	}
 
+	if stale != nil {
+		if stale.ETag != "" {
+			request.Header.Set("If-None-Match", stale.ETag)
+		}
+
+		if stale.LastModified != "" {
+			request.Header.Set("If-Modified-Since", stale.LastModified)
+		}
+	}
+
+	c.logger.Debug("Sending the request", "url", url, "conditional", stale != nil)
+
+	start := time.Now()
+
 	resp, err := c.httpClient.Do(request)
 	if err != nil {
-		return []byte{}, fmt.Errorf("error getting the response from the server: %w", err)
+		return response{}, &NetworkError{URL: url, Err: fmt.Errorf("error getting the response from the server: %w", err)}
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		return []byte{}, fmt.Errorf(
-			"received a bad status from %s: (%d) %s",
-			url,
-			resp.StatusCode,
-			resp.Status,
-		)
+	c.logger.Info("Received the response", "url", url, "status", resp.StatusCode, "duration", time.Since(start))
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && stale != nil:
+		return response{
+			data:         stale.Value,
+			etag:         stale.ETag,
+			lastModified: stale.LastModified,
+			expiresAt:    expiresAt(resp.Header),
+			notModified:  true,
+		}, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return response{}, &NotFoundError{URL: url}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return response{}, &RateLimitedError{URL: url, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	case resp.StatusCode >= 500:
+		return response{}, &ServerError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
+	case resp.StatusCode >= 300:
+		return response{}, &StatusError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return []byte{}, fmt.Errorf(
-			"unable to read the response from the server: %w",
-			err,
-		)
+		return response{}, &NetworkError{URL: url, Err: fmt.Errorf("unable to read the response from the server: %w", err)}
+	}
+
+	return response{
+		data:         data,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		expiresAt:    expiresAt(resp.Header),
+		notModified:  false,
+	}, nil
+}
+
+// expiresAt returns when a response becomes stale according to the max-age
+// directive of its Cache-Control header. The zero time is returned if the
+// header does not specify it.
+func expiresAt(header http.Header) time.Time {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(strings.ToLower(directive))
+
+		if directive == "no-cache" {
+			return time.Now()
+		}
+
+		value, found := strings.CutPrefix(directive, "max-age=")
+		if !found {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+
+		return time.Now().Add(time.Duration(max(seconds, 0)) * time.Second)
 	}
 
-	return data, nil
+	return time.Time{}
 }
 
 func decodeJSON(data []byte, value any) error {
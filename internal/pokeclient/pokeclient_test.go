@@ -0,0 +1,119 @@
+package pokeclient_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+const (
+	encountersPath = "/api/v2/pokemon/25/encounters"
+	encountersBody = `[{"location_area": {"name": "viridian-forest-area", "url": ""}, "version_details": []}]`
+)
+
+func TestGetPokemonLocationAreasIsCached(t *testing.T) {
+	server := pokeapitest.NewServer(t)
+	server.HandleJSON(encountersPath, encountersBody)
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	for range 2 {
+		encounters, err := client.GetPokemonLocationAreas(context.Background(), server.URL+encountersPath)
+		if err != nil {
+			t.Fatalf("Unable to get the encounter areas: %v", err)
+		}
+
+		if len(encounters) != 1 || encounters[0].LocationArea.Name != "viridian-forest-area" {
+			t.Fatalf("Unexpected encounter areas: %+v", encounters)
+		}
+	}
+
+	if got := server.Requests(encountersPath); got != 1 {
+		t.Errorf("Unexpected number of requests for the encounter areas: want 1, got %d", got)
+	}
+}
+
+func TestCancelledRequest(t *testing.T) {
+	const path = "/api/v2/pokemon/pikachu/"
+
+	release := make(chan struct{})
+	defer close(release)
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(path, func(_ http.ResponseWriter, request *http.Request) {
+		select {
+		case <-release:
+		case <-request.Context().Done():
+		}
+	})
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err := client.GetPokemon(ctx, "pikachu")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Unexpected error: want %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("The cancelled request took too long to return: %v", elapsed)
+	}
+
+	if got := server.Requests(path); got != 1 {
+		t.Errorf("The cancelled request was retried: want 1 request, got %d", got)
+	}
+}
+
+func TestLogging(t *testing.T) {
+	const path = "/api/v2/pokemon/pikachu/"
+
+	server := pokeapitest.NewServer(t)
+	server.HandleJSON(path, `{"id": 25, "name": "pikachu"}`)
+
+	var logs bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL(server.URL),
+		pokeclient.WithLogger(logger),
+	)
+	defer client.Close()
+
+	for range 2 {
+		if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+			t.Fatalf("Unable to get the Pokemon: %v", err)
+		}
+	}
+
+	want := []string{
+		`msg="Received the response" url=` + server.URL + path + " status=200",
+		`msg="Using the data from the cache" url=` + server.URL + path,
+	}
+
+	for _, line := range want {
+		if !strings.Contains(logs.String(), line) {
+			t.Errorf("The logs do not contain %q:\n%s", line, logs.String())
+		}
+	}
+
+	if strings.Contains(logs.String(), "level=DEBUG") {
+		t.Errorf("Debug messages were logged at the info level:\n%s", logs.String())
+	}
+}
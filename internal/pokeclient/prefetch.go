@@ -0,0 +1,76 @@
+package pokeclient
+
+import "slices"
+
+// Prefetch fetches the named resources from the endpoint in the background so
+// that they can be served from the cache when they are needed. Resources that
+// are already fresh in the cache are skipped, and any errors are ignored
+// since the resources will be requested again when they are needed. The
+// resources are fetched by a pool of at most as many workers as the client's
+// maximum number of concurrent requests. The prefetches are cancelled when the
+// client is closed, and nothing is prefetched after that.
+func Prefetch[T any](client *Client, resource Resource[T], names ...string) {
+	urls := make([]string, 0, len(names))
+
+	for _, name := range slices.All(names) {
+		url := client.resourceURL(resource.Path, name)
+
+		if _, fresh, exists := client.cache.Peek(url); exists && fresh {
+			continue
+		}
+
+		urls = append(urls, url)
+	}
+
+	if len(urls) == 0 {
+		return
+	}
+
+	queue := make(chan string, len(urls))
+
+	for _, url := range slices.All(urls) {
+		queue <- url
+	}
+
+	close(queue)
+
+	decode := func(data []byte) error {
+		var value T
+
+		return decodeJSON(data, &value)
+	}
+
+	workers := min(client.maxConcurrentRequests, len(urls))
+
+	client.prefetchMu.Lock()
+	defer client.prefetchMu.Unlock()
+
+	if client.ctx.Err() != nil {
+		return
+	}
+
+	client.prefetches.Add(workers)
+
+	for range workers {
+		go func() {
+			defer client.prefetches.Done()
+
+			for url := range queue {
+				if client.ctx.Err() != nil {
+					return
+				}
+
+				client.logger.Debug("Prefetching the resource", "url", url)
+
+				_, _, _ = client.flights.do(client.ctx, url, func() ([]byte, source, error) {
+					return client.fetchFromServer(client.ctx, url, decode)
+				})
+			}
+		}()
+	}
+}
+
+// WaitForPrefetches waits for all the prefetches in the background to finish.
+func (c *Client) WaitForPrefetches() {
+	c.prefetches.Wait()
+}
@@ -0,0 +1,325 @@
+package pokeclient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func TestConcurrentRequestsAreDeduplicated(t *testing.T) {
+	const (
+		path     = "/api/v2/pokemon/pikachu/"
+		numUsers = 10
+	)
+
+	release := make(chan struct{})
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(path, func(writer http.ResponseWriter, _ *http.Request) {
+		<-release
+
+		_, _ = writer.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+	})
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	var waitGroup sync.WaitGroup
+
+	errs := make(chan error, numUsers)
+
+	for range numUsers {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+			if err != nil {
+				errs <- err
+
+				return
+			}
+
+			if pokemon.Name != "pikachu" {
+				errs <- fmt.Errorf("unexpected Pokemon: want pikachu, got %s", pokemon.Name)
+			}
+		}()
+	}
+
+	// Give the other requests time to join the one that is in flight.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	waitGroup.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Unable to get the Pokemon: %v", err)
+	}
+
+	if got := server.Requests(path); got != 1 {
+		t.Errorf("Unexpected number of requests for the Pokemon: want 1, got %d", got)
+	}
+}
+
+func TestPrefetch(t *testing.T) {
+	names := []string{"pallet-town-area", "viridian-forest-area", "mt-moon-1f"}
+
+	server := pokeapitest.NewServer(t)
+
+	for _, name := range names {
+		server.HandleJSON("/api/v2/location-area/"+name+"/", fmt.Sprintf(`{"id": 1, "name": %q}`, name))
+	}
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	pokeclient.Prefetch(client, pokeclient.LocationAreaResource, names...)
+	client.WaitForPrefetches()
+
+	for _, name := range names {
+		area, err := client.GetLocationArea(context.Background(), name)
+		if err != nil {
+			t.Fatalf("Unable to get the location area %s: %v", name, err)
+		}
+
+		if area.Name != name {
+			t.Errorf("Unexpected location area: want %s, got %s", name, area.Name)
+		}
+
+		if got := server.Requests("/api/v2/location-area/" + name + "/"); got != 1 {
+			t.Errorf("Unexpected number of requests for %s: want 1, got %d", name, got)
+		}
+	}
+
+	if stats := client.CacheStats(); stats.Hits != len(names) {
+		t.Errorf("Unexpected number of cache hits: want %d, got %d", len(names), stats.Hits)
+	}
+
+	pokeclient.Prefetch(client, pokeclient.LocationAreaResource, names...)
+	client.WaitForPrefetches()
+
+	if got := server.TotalRequests(); got != len(names) {
+		t.Errorf("Fresh resources were prefetched again: want %d requests, got %d", len(names), got)
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	const maxConcurrentRequests = 2
+
+	var (
+		active    atomic.Int32
+		maxActive atomic.Int32
+	)
+
+	server := pokeapitest.NewServer(t)
+	server.Handle("/api/v2/location-area/", func(writer http.ResponseWriter, _ *http.Request) {
+		current := active.Add(1)
+		defer active.Add(-1)
+
+		for {
+			highest := maxActive.Load()
+			if current <= highest || maxActive.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		_, _ = writer.Write([]byte(`{"id": 1, "name": "area"}`))
+	})
+
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL(server.URL),
+		pokeclient.WithMaxConcurrentRequests(maxConcurrentRequests),
+	)
+	defer client.Close()
+
+	names := make([]string, 8)
+	for ind := range names {
+		names[ind] = fmt.Sprintf("area-%d", ind)
+	}
+
+	pokeclient.Prefetch(client, pokeclient.LocationAreaResource, names...)
+	client.WaitForPrefetches()
+
+	if got := server.TotalRequests(); got != len(names) {
+		t.Errorf("Unexpected number of requests: want %d, got %d", len(names), got)
+	}
+
+	if got := maxActive.Load(); got > maxConcurrentRequests {
+		t.Errorf("Too many concurrent requests: want at most %d, got %d", maxConcurrentRequests, got)
+	}
+}
+
+func TestWaiterRetriesAfterTheFirstCallerCancels(t *testing.T) {
+	const path = "/api/v2/pokemon/pikachu/"
+
+	var calls atomic.Int32
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(path, func(writer http.ResponseWriter, request *http.Request) {
+		// The first request hangs until it is cancelled by the first caller.
+		if calls.Add(1) == 1 {
+			<-request.Context().Done()
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+	})
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	firstErr := make(chan error, 1)
+
+	go func() {
+		_, err := client.GetPokemon(ctx, "pikachu")
+		firstErr <- err
+	}()
+
+	for server.Requests(path) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	type result struct {
+		pokemon string
+		err     error
+	}
+
+	waiterResult := make(chan result, 1)
+
+	go func() {
+		pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+		waiterResult <- result{pokemon: pokemon.Name, err: err}
+	}()
+
+	// Give the second caller time to join the request that is in flight.
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error for the first caller: want %v, got %v", context.Canceled, err)
+	}
+
+	got := <-waiterResult
+	if got.err != nil {
+		t.Fatalf("Unable to get the Pokemon after the first caller cancelled: %v", got.err)
+	}
+
+	if got.pokemon != "pikachu" {
+		t.Errorf("Unexpected Pokemon: want pikachu, got %s", got.pokemon)
+	}
+
+	if got := server.Requests(path); got != 2 {
+		t.Errorf("Unexpected number of requests for the Pokemon: want 2, got %d", got)
+	}
+}
+
+func TestPrefetchUsesABoundedPoolOfWorkers(t *testing.T) {
+	const (
+		numNames              = 200
+		maxConcurrentRequests = 2
+	)
+
+	release := make(chan struct{})
+
+	server := pokeapitest.NewServer(t)
+
+	names := make([]string, numNames)
+
+	for ind := range numNames {
+		names[ind] = fmt.Sprintf("area-%d", ind)
+
+		server.Handle("/api/v2/location-area/"+names[ind]+"/", func(writer http.ResponseWriter, _ *http.Request) {
+			<-release
+
+			_, _ = fmt.Fprintf(writer, `{"id": %d, "name": %q}`, ind+1, names[ind])
+		})
+	}
+
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL(server.URL),
+		pokeclient.WithMaxConcurrentRequests(maxConcurrentRequests),
+	)
+	defer client.Close()
+
+	before := runtime.NumGoroutine()
+
+	pokeclient.Prefetch(client, pokeclient.LocationAreaResource, names...)
+
+	for server.TotalRequests() < maxConcurrentRequests {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Each worker adds a few goroutines for its connection to the test server,
+	// but nowhere near one goroutine for each name.
+	if added := runtime.NumGoroutine() - before; added > 10*maxConcurrentRequests {
+		t.Errorf("Too many goroutines were started for the prefetch: want at most %d, got %d", 10*maxConcurrentRequests, added)
+	}
+
+	close(release)
+	client.WaitForPrefetches()
+
+	if got := server.TotalRequests(); got != numNames {
+		t.Errorf("Unexpected number of requests: want %d, got %d", numNames, got)
+	}
+}
+
+func TestPrefetchAfterClose(t *testing.T) {
+	names := []string{"pallet-town-area", "viridian-forest-area", "mt-moon-1f"}
+
+	server := pokeapitest.NewServer(t)
+
+	for _, name := range names {
+		server.HandleJSON("/api/v2/location-area/"+name+"/", fmt.Sprintf(`{"id": 1, "name": %q}`, name))
+	}
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+
+	var waitGroup sync.WaitGroup
+
+	// Prefetching while the client is being closed must not race with Close.
+	for range 10 {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			pokeclient.Prefetch(client, pokeclient.LocationAreaResource, names...)
+		}()
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Unable to close the client: %v", err)
+	}
+
+	waitGroup.Wait()
+
+	requests := server.TotalRequests()
+
+	pokeclient.Prefetch(client, pokeclient.LocationAreaResource, names...)
+	client.WaitForPrefetches()
+
+	if got := server.TotalRequests(); got != requests {
+		t.Errorf("Resources were prefetched after the client was closed: want %d requests, got %d", requests, got)
+	}
+}
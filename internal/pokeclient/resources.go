@@ -0,0 +1,175 @@
+package pokeclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokecache"
+)
+
+// Resource is a PokeAPI endpoint that serves resources of type T.
+// Adding support for a new endpoint only requires its model and path.
+type Resource[T any] struct {
+	Path string
+}
+
+// The registry of PokeAPI endpoints supported by the client.
+var (
+	AbilityResource        = Resource[pokeapi.Ability]{Path: "/api/v2/ability"}
+	BerryResource          = Resource[pokeapi.Berry]{Path: "/api/v2/berry"}
+	ItemResource           = Resource[pokeapi.Item]{Path: "/api/v2/item"}
+	LocationAreaResource   = Resource[pokeapi.LocationArea]{Path: "/api/v2/location-area"}
+	PokemonResource        = Resource[pokeapi.Pokemon]{Path: "/api/v2/pokemon"}
+	PokemonSpeciesResource = Resource[pokeapi.PokemonSpecies]{Path: "/api/v2/pokemon-species"}
+	TypeResource           = Resource[pokeapi.Type]{Path: "/api/v2/type"}
+)
+
+// Get gets the named resource from the endpoint.
+func Get[T any](ctx context.Context, client *Client, resource Resource[T], name string) (T, error) {
+	return GetURL[T](ctx, client, client.resourceURL(resource.Path, name))
+}
+
+// GetURL gets the data from the URL and decodes it into a value of type T.
+// The data is served from the cache if it is fresh, otherwise it is requested
+// from the server and cached once it has been successfully decoded. Stale data
+// in the cache is revalidated with the server before it is used. Concurrent
+// requests for the same URL share a single request to the server.
+func GetURL[T any](ctx context.Context, client *Client, url string) (T, error) {
+	var (
+		value   T
+		decoded bool
+	)
+
+	decode := func(data []byte) error {
+		if err := decodeJSON(data, &value); err != nil {
+			return err
+		}
+
+		decoded = true
+
+		return nil
+	}
+
+	data, source, err := client.fetch(ctx, url, decode)
+	if err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	if !decoded {
+		if err := decodeJSON(data, &value); err != nil {
+			var zero T
+
+			return zero, fmt.Errorf("unable to decode the data from the %s: %w", source, err)
+		}
+	}
+
+	return value, nil
+}
+
+// source is where the data for a request came from.
+type source string
+
+const (
+	sourceServer      source = "server"
+	sourceCache       source = "cache"
+	sourceRevalidated source = "revalidated cache"
+)
+
+// fetch returns the data for the URL from the cache if it is fresh, otherwise
+// it fetches the data from the server. The data from the server is only
+// cached if it is accepted by the decode function.
+func (c *Client) fetch(ctx context.Context, url string, decode func([]byte) error) ([]byte, source, error) {
+	entry, fresh, exists := c.cache.Lookup(url)
+	if exists && fresh {
+		c.logger.Info("Using the data from the cache", "url", url)
+
+		return entry.Value, sourceCache, nil
+	}
+
+	return c.flights.do(ctx, url, func() ([]byte, source, error) {
+		return c.fetchFromServer(ctx, url, decode)
+	})
+}
+
+func (c *Client) fetchFromServer(ctx context.Context, url string, decode func([]byte) error) ([]byte, source, error) {
+	// The entry may have been cached by a request that finished
+	// after this one looked it up.
+	entry, fresh, exists := c.cache.Peek(url)
+	if exists && fresh {
+		c.logger.Info("Using the data from the cache", "url", url)
+
+		return entry.Value, sourceCache, nil
+	}
+
+	var stale *pokecache.Entry
+	if exists {
+		stale = &entry
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, "", fmt.Errorf("the request was abandoned while waiting to be sent: %w", ctx.Err())
+	case c.requestSlots <- struct{}{}:
+	}
+
+	resp, err := c.sendRequest(ctx, url, stale)
+	<-c.requestSlots
+
+	if err != nil {
+		return nil, "", fmt.Errorf(
+			"received an error after sending the request to the server: %w",
+			err,
+		)
+	}
+
+	if resp.notModified && c.cache.Refresh(url, resp.expiresAt) {
+		c.logger.Info("Using the revalidated data from the cache", "url", url)
+
+		return resp.data, sourceRevalidated, nil
+	}
+
+	if err := decode(resp.data); err != nil {
+		return nil, "", fmt.Errorf("unable to decode the data from the server: %w", err)
+	}
+
+	c.cache.AddEntry(url, pokecache.Entry{
+		Value:        resp.data,
+		ETag:         resp.etag,
+		LastModified: resp.lastModified,
+		ExpiresAt:    resp.expiresAt,
+	})
+
+	return resp.data, sourceServer, nil
+}
+
+func (c *Client) resourceURL(path, name string) string {
+	return c.baseURL + path + "/" + name + "/"
+}
+
+// maxListLimit is large enough to list every resource from an endpoint in a single page.
+const maxListLimit = 100000
+
+// Names returns the names of every resource from the endpoint. The full list is
+// cached like any other response so it can be used as an index of names for
+// suggestions and completions.
+func Names[T any](ctx context.Context, client *Client, resource Resource[T]) ([]string, error) {
+	url := client.baseURL + resource.Path + "?limit=" + strconv.Itoa(maxListLimit) + "&offset=0"
+
+	list, err := client.GetNamedAPIResourceList(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the list of names: %w", err)
+	}
+
+	names := make([]string, len(list.Results))
+
+	for ind, result := range slices.All(list.Results) {
+		names[ind] = result.Name
+	}
+
+	return names, nil
+}
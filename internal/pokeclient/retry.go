@@ -0,0 +1,102 @@
+package pokeclient
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+// Requests are retried after transient network errors, server errors and
+// rate limiting, with a jittered exponential backoff between attempts.
+// Any other error, such as a request that cannot be created, is permanent and
+// is not retried. The deadline bounds the total time spent on the request
+// including every attempt and the delays between them. A zero deadline means
+// that there is no overall deadline.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Deadline    time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    4 * time.Second,
+		Deadline:    30 * time.Second,
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// backoff returns how long to wait before the next attempt. The delay doubles
+// after each attempt up to the maximum delay, and the actual delay is a random
+// duration between half and all of it.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << min(attempt-1, 30)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	half := delay / 2
+
+	if half <= 0 {
+		return delay
+	}
+
+	return half + rand.N(half+1)
+}
+
+// retryDelay returns how long to wait before retrying after the error, and
+// false if the request should not be retried.
+func (p RetryPolicy) retryDelay(err error, attempt int) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	var (
+		rateLimited *RateLimitedError
+		serverErr   *ServerError
+		networkErr  *NetworkError
+	)
+
+	switch {
+	case errors.As(err, &rateLimited):
+		if rateLimited.RetryAfter > 0 {
+			return rateLimited.RetryAfter, true
+		}
+
+		return p.backoff(attempt), true
+	case errors.As(err, &serverErr), errors.As(err, &networkErr):
+		return p.backoff(attempt), true
+	default:
+		return 0, false
+	}
+}
+
+// parseRetryAfter parses the value of the Retry-After header which is
+// either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,233 @@
+package pokeclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func testRetryPolicy() pokeclient.RetryPolicy {
+	return pokeclient.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		Deadline:    5 * time.Second,
+	}
+}
+
+func TestRetryAfterServerErrors(t *testing.T) {
+	const path = "/api/v2/pokemon/pikachu/"
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(path, func(writer http.ResponseWriter, _ *http.Request) {
+		if server.Requests(path) < 3 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+	})
+
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL(server.URL),
+		pokeclient.WithRetryPolicy(testRetryPolicy()),
+	)
+	defer client.Close()
+
+	pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("Unable to get pikachu: %v", err)
+	}
+
+	if pokemon.ID != 25 {
+		t.Errorf("Unexpected Pokemon ID: want 25, got %d", pokemon.ID)
+	}
+
+	if got := server.Requests(path); got != 3 {
+		t.Errorf("Unexpected number of requests: want 3, got %d", got)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	const path = "/api/v2/pokemon/pikachu/"
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(path, func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusInternalServerError)
+	})
+
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL(server.URL),
+		pokeclient.WithRetryPolicy(testRetryPolicy()),
+	)
+	defer client.Close()
+
+	_, err := client.GetPokemon(context.Background(), "pikachu")
+
+	var serverErr *pokeclient.ServerError
+	if !errors.As(err, &serverErr) {
+		t.Fatalf("Unexpected error: want a ServerError, got %v", err)
+	}
+
+	if got := server.Requests(path); got != 3 {
+		t.Errorf("Unexpected number of requests: want 3, got %d", got)
+	}
+}
+
+func TestRetryAfterRateLimiting(t *testing.T) {
+	const path = "/api/v2/pokemon/pikachu/"
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(path, func(writer http.ResponseWriter, _ *http.Request) {
+		if server.Requests(path) == 1 {
+			writer.Header().Set("Retry-After", "1")
+			writer.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+	})
+
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL(server.URL),
+		pokeclient.WithRetryPolicy(testRetryPolicy()),
+	)
+	defer client.Close()
+
+	start := time.Now()
+
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("Unable to get pikachu: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("The client did not honour the Retry-After header: retried after %s", elapsed)
+	}
+}
+
+func TestNoRetryWhenNotFound(t *testing.T) {
+	server := pokeapitest.NewServer(t)
+
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL(server.URL),
+		pokeclient.WithRetryPolicy(testRetryPolicy()),
+	)
+	defer client.Close()
+
+	_, err := client.GetPokemon(context.Background(), "pikachuu")
+
+	var notFound *pokeclient.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Unexpected error: want a NotFoundError, got %v", err)
+	}
+
+	if got := server.TotalRequests(); got != 1 {
+		t.Errorf("Unexpected number of requests: want 1, got %d", got)
+	}
+}
+
+func TestRetryAfterNetworkErrors(t *testing.T) {
+	const path = "/api/v2/pokemon/pikachu/"
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(path, func(writer http.ResponseWriter, _ *http.Request) {
+		if server.Requests(path) < 3 {
+			// Drop the connection without sending a response.
+			if conn, _, err := http.NewResponseController(writer).Hijack(); err == nil {
+				_ = conn.Close()
+			}
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+	})
+
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL(server.URL),
+		pokeclient.WithRetryPolicy(testRetryPolicy()),
+	)
+	defer client.Close()
+
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("Unable to get pikachu: %v", err)
+	}
+
+	if got := server.Requests(path); got != 3 {
+		t.Errorf("Unexpected number of requests: want 3, got %d", got)
+	}
+}
+
+func TestNoRetryForPermanentErrors(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.BaseDelay = time.Second
+	policy.MaxDelay = time.Second
+
+	// The invalid escape in the base URL means that the request cannot be created.
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL("http://pokeapi.test/%zz"),
+		pokeclient.WithRetryPolicy(policy),
+	)
+	defer client.Close()
+
+	start := time.Now()
+
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err == nil {
+		t.Fatal("Expected an error after requesting an invalid URL")
+	}
+
+	if elapsed := time.Since(start); elapsed >= policy.BaseDelay/2 {
+		t.Errorf("The request that could not be created was retried: returned after %s", elapsed)
+	}
+}
+
+func TestRetryDeadlineBoundsTheAttempt(t *testing.T) {
+	const path = "/api/v2/pokemon/pikachu/"
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(path, func(_ http.ResponseWriter, request *http.Request) {
+		<-request.Context().Done()
+	})
+
+	policy := testRetryPolicy()
+	policy.Deadline = 50 * time.Millisecond
+
+	// The timeout for each attempt is much longer than the deadline.
+	client := pokeclient.NewClient(
+		time.Minute,
+		5*time.Second,
+		pokeclient.WithBaseURL(server.URL),
+		pokeclient.WithRetryPolicy(policy),
+	)
+	defer client.Close()
+
+	start := time.Now()
+
+	_, err := client.GetPokemon(context.Background(), "pikachu")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error: want %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("The request ran past the retry deadline: returned after %s", elapsed)
+	}
+}
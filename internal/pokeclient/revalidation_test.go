@@ -0,0 +1,58 @@
+package pokeclient_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func TestStaleEntriesAreRevalidated(t *testing.T) {
+	const (
+		path = "/api/v2/pokemon/pikachu/"
+		etag = `"pikachu-v1"`
+	)
+
+	notModified := 0
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(path, func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("ETag", etag)
+		writer.Header().Set("Cache-Control", "public, max-age=0")
+
+		if request.Header.Get("If-None-Match") == etag {
+			notModified++
+
+			writer.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+	})
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	for range 3 {
+		pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+		if err != nil {
+			t.Fatalf("Unable to get pikachu: %v", err)
+		}
+
+		if pokemon.ID != 25 {
+			t.Fatalf("Unexpected Pokemon ID: want 25, got %d", pokemon.ID)
+		}
+	}
+
+	if got := server.Requests(path); got != 3 {
+		t.Errorf("Unexpected number of requests: want 3, got %d", got)
+	}
+
+	if notModified != 2 {
+		t.Errorf("Unexpected number of 304 responses: want 2, got %d", notModified)
+	}
+}
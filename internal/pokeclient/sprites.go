@@ -0,0 +1,90 @@
+package pokeclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/atomicfile"
+)
+
+// WithSpriteCacheDir sets the directory where the sprites are cached on disk
+// so that they do not need to be downloaded again after the application
+// restarts. By default the sprites are only cached in memory.
+func WithSpriteCacheDir(dir string) Option {
+	return func(c *Client) {
+		c.spriteCacheDir = dir
+	}
+}
+
+// GetSprite gets the PNG image of a sprite from the URL. The image is served
+// from the sprite cache on disk if it has been downloaded before.
+func (c *Client) GetSprite(ctx context.Context, url string) (image.Image, error) {
+	if url == "" {
+		return nil, errors.New("the Pokemon does not have this sprite")
+	}
+
+	path := c.spritePath(url)
+
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			if img, err := png.Decode(bytes.NewReader(data)); err == nil {
+				c.logger.Info("Using the sprite from the disk cache", "url", url, "path", path)
+
+				return img, nil
+			}
+		}
+	}
+
+	var img image.Image
+
+	decode := func(data []byte) error {
+		var err error
+
+		img, err = png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("unable to decode the PNG image: %w", err)
+		}
+
+		return nil
+	}
+
+	data, source, err := c.fetch(ctx, url, decode)
+	if err != nil {
+		return nil, err
+	}
+
+	if img == nil {
+		if err := decode(data); err != nil {
+			return nil, fmt.Errorf("unable to decode the sprite from the %s: %w", source, err)
+		}
+	}
+
+	if path != "" {
+		if err := atomicfile.Write(path, data); err != nil {
+			c.logger.Warn("Unable to save the sprite to the disk cache", "url", url, "error", err)
+		}
+	}
+
+	return img, nil
+}
+
+// spritePath returns the path of the sprite in the disk cache. The file is
+// named after the hash of the URL since the sprites of different Pokemon
+// can share the same file name.
+func (c *Client) spritePath(url string) string {
+	if c.spriteCacheDir == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(url))
+
+	return filepath.Join(c.spriteCacheDir, hex.EncodeToString(sum[:])+".png")
+}
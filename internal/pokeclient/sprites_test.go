@@ -0,0 +1,62 @@
+package pokeclient_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+)
+
+func TestSpritesAreCachedOnDisk(t *testing.T) {
+	const spritePath = "/sprites/pokemon/25.png"
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.NRGBA{R: 255, G: 204, B: 0, A: 255})
+
+	var data bytes.Buffer
+
+	if err := png.Encode(&data, img); err != nil {
+		t.Fatalf("Unable to encode the test sprite: %v", err)
+	}
+
+	server := pokeapitest.NewServer(t)
+	server.Handle(spritePath, func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Content-Type", "image/png")
+		_, _ = writer.Write(data.Bytes())
+	})
+
+	cacheDir := t.TempDir()
+
+	// The second client starts with an empty memory cache so
+	// it can only get the sprite from the disk or the server.
+	for range 2 {
+		client := pokeclient.NewClient(
+			time.Minute,
+			5*time.Second,
+			pokeclient.WithBaseURL(server.URL),
+			pokeclient.WithSpriteCacheDir(cacheDir),
+		)
+
+		sprite, err := client.GetSprite(context.Background(), server.URL+spritePath)
+		if err != nil {
+			t.Fatalf("Unable to get the sprite: %v", err)
+		}
+
+		if got := color.NRGBAModel.Convert(sprite.At(0, 0)); got != (color.NRGBA{R: 255, G: 204, B: 0, A: 255}) {
+			t.Errorf("Unexpected colour of the sprite's first pixel: got %v", got)
+		}
+
+		_ = client.Close()
+	}
+
+	if got := server.Requests(spritePath); got != 1 {
+		t.Errorf("Unexpected number of requests for the sprite: want 1, got %d", got)
+	}
+}
@@ -0,0 +1,185 @@
+package pokelink
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrade"
+)
+
+const (
+	battleLevel     = 50
+	battleMovePower = 60
+	battleMaxTurns  = 100
+)
+
+var ErrBattleMismatch = errors.New("the battle results from both peers do not match")
+
+// BattleResult is the outcome of a battle from the point of view of the local peer.
+type BattleResult struct {
+	Won   bool
+	Turns int
+	Log   []string
+}
+
+type combatant struct {
+	label          string
+	hp             int
+	maxHP          int
+	attack         int
+	defense        int
+	specialAttack  int
+	specialDefense int
+	speed          int
+}
+
+func newCombatant(label string, pokemon pokeapi.Pokemon) combatant {
+	stats := make(map[string]int)
+
+	for _, stat := range slices.All(pokemon.Stats) {
+		stats[stat.Stat.Name] = stat.BaseStat
+	}
+
+	// A simplified version of the stat formulas for a Pokemon at level 50.
+	maxHP := stats["hp"] + battleLevel + 10
+
+	return combatant{
+		label:          label,
+		hp:             maxHP,
+		maxHP:          maxHP,
+		attack:         stats["attack"] + 5,
+		defense:        stats["defense"] + 5,
+		specialAttack:  stats["special-attack"] + 5,
+		specialDefense: stats["special-defense"] + 5,
+		speed:          stats["speed"] + 5,
+	}
+}
+
+// damage calculates the damage that the attacker deals to the defender using
+// whichever of its physical or special attacks is the most effective.
+func (c combatant) damage(defender combatant, roller *rand.Rand) int {
+	physical := float64(c.attack) / float64(max(defender.defense, 1))
+	special := float64(c.specialAttack) / float64(max(defender.specialDefense, 1))
+	ratio := max(physical, special)
+
+	base := (float64(2*battleLevel/5+2)*battleMovePower*ratio)/50 + 2
+	multiplier := float64(85+roller.IntN(16)) / 100
+
+	return max(int(base*multiplier), 1)
+}
+
+// Battle battles the Pokemon against the peer's Pokemon. The host chooses the
+// seed for the battle so that both peers simulate the same battle, and the
+// results are exchanged afterwards to check that they agree.
+func (p *Peer) Battle(name string, pokemon pokeapi.Pokemon) (BattleResult, error) {
+	pkg, err := poketrade.NewPackage(poketrade.TradedPokemon{
+		Name:       name,
+		Pokemon:    pokemon,
+		Individual: nil,
+		HeldItem:   "",
+	})
+	if err != nil {
+		return BattleResult{}, fmt.Errorf("unable to package %s for battle: %w", name, err)
+	}
+
+	offer := BattleOffer{Package: pkg, Seed: 0}
+	if p.role == RoleHost {
+		offer.Seed = rand.Uint64()
+	}
+
+	var peerOffer BattleOffer
+
+	if err := p.exchange(MessageBattleOffer, offer, &peerOffer); err != nil {
+		return BattleResult{}, fmt.Errorf("unable to exchange battle offers: %w", err)
+	}
+
+	peer, err := peerOffer.Package.Unpack()
+	if err != nil {
+		_ = p.SendError(err)
+
+		return BattleResult{}, fmt.Errorf("the peer's Pokemon is invalid: %w", err)
+	}
+
+	local := newCombatant("Your "+name, pokemon)
+	remote := newCombatant("The opponent's "+peer.Name, peer.Pokemon)
+
+	var (
+		hostWon bool
+		turns   int
+		log     []string
+	)
+
+	if p.role == RoleHost {
+		hostWon, turns, log = simulateBattle(local, remote, offer.Seed)
+	} else {
+		hostWon, turns, log = simulateBattle(remote, local, peerOffer.Seed)
+	}
+
+	winner := "host"
+	if !hostWon {
+		winner = "guest"
+	}
+
+	var peerResult BattleResultMessage
+
+	if err := p.exchange(MessageBattleResult, BattleResultMessage{Winner: winner, Turns: turns}, &peerResult); err != nil {
+		return BattleResult{}, fmt.Errorf("unable to exchange battle results: %w", err)
+	}
+
+	if peerResult.Winner != winner || peerResult.Turns != turns {
+		return BattleResult{}, ErrBattleMismatch
+	}
+
+	result := BattleResult{
+		Won:   hostWon == (p.role == RoleHost),
+		Turns: turns,
+		Log:   log,
+	}
+
+	return result, nil
+}
+
+// simulateBattle simulates a battle between the host's and the guest's Pokemon.
+// The faster Pokemon attacks first in each turn and the battle ends when one of
+// the Pokemon faints. If neither Pokemon faints within the maximum number of turns
+// then the Pokemon with the largest share of its HP remaining wins.
+func simulateBattle(host, guest combatant, seed uint64) (bool, int, []string) {
+	roller := rand.New(rand.NewPCG(seed, seed))
+	log := make([]string, 0)
+
+	first, second := &host, &guest
+	if guest.speed > host.speed || (guest.speed == host.speed && roller.IntN(2) == 1) {
+		first, second = &guest, &host
+	}
+
+	for turn := 1; turn <= battleMaxTurns; turn++ {
+		for _, pair := range [][2]*combatant{{first, second}, {second, first}} {
+			attacker, defender := pair[0], pair[1]
+
+			damage := attacker.damage(*defender, roller)
+			defender.hp = max(defender.hp-damage, 0)
+
+			log = append(log, fmt.Sprintf(
+				"%s attacks for %d damage (%s: %d/%d HP)",
+				attacker.label,
+				damage,
+				defender.label,
+				defender.hp,
+				defender.maxHP,
+			))
+
+			if defender.hp == 0 {
+				log = append(log, defender.label+" fainted!")
+
+				return defender == &guest, turn, log
+			}
+		}
+	}
+
+	hostWon := host.hp*guest.maxHP >= guest.hp*host.maxHP
+
+	return hostWon, battleMaxTurns, log
+}
@@ -0,0 +1,229 @@
+package pokelink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+type Role int
+
+const (
+	RoleHost Role = iota
+	RoleGuest
+)
+
+// Peer is one end of a connection between two pokecli instances.
+// The guest always speaks first in each exchange of messages so that
+// the protocol works over both buffered and unbuffered connections.
+type Peer struct {
+	conn    net.Conn
+	role    Role
+	timeout time.Duration
+	encoder *json.Encoder
+	scanner *bufio.Scanner
+}
+
+// Listener listens for a trainer to join from another pokecli instance.
+type Listener struct {
+	listener net.Listener
+	timeout  time.Duration
+}
+
+func NewPeer(conn net.Conn, role Role, timeout time.Duration) *Peer {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	peer := Peer{
+		conn:    conn,
+		role:    role,
+		timeout: timeout,
+		encoder: json.NewEncoder(conn),
+		scanner: scanner,
+	}
+
+	return &peer
+}
+
+// Listen listens for a guest on the TCP address.
+func Listen(address string, timeout time.Duration) (*Listener, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on %s: %w", address, err)
+	}
+
+	return &Listener{listener: listener, timeout: timeout}, nil
+}
+
+// Addr returns the address that the listener is listening on.
+func (l *Listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Accept waits for a guest to join and completes the handshake.
+func (l *Listener) Accept(mode Mode) (*Peer, error) {
+	if tcpListener, ok := l.listener.(*net.TCPListener); ok {
+		if err := tcpListener.SetDeadline(time.Now().Add(l.timeout)); err != nil {
+			return nil, fmt.Errorf("unable to set the deadline for accepting a connection: %w", err)
+		}
+	}
+
+	conn, err := l.listener.Accept()
+	if err != nil {
+		return nil, fmt.Errorf("unable to accept a connection: %w", err)
+	}
+
+	peer := NewPeer(conn, RoleHost, l.timeout)
+
+	if err := peer.Handshake(mode); err != nil {
+		_ = peer.Close()
+
+		return nil, err
+	}
+
+	return peer, nil
+}
+
+func (l *Listener) Close() error {
+	return l.listener.Close()
+}
+
+// Join connects to the host at the TCP address and completes the handshake.
+// Cancelling the context stops the attempt to join.
+func Join(ctx context.Context, address string, mode Mode, timeout time.Duration) (*Peer, error) {
+	dialer := net.Dialer{Timeout: timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %w", address, err)
+	}
+
+	peer := NewPeer(conn, RoleGuest, timeout)
+
+	// A host that accepts the connection but never replies would otherwise
+	// keep the handshake waiting until the timeout.
+	stopHandshake := context.AfterFunc(ctx, func() { _ = peer.Close() })
+
+	err = peer.Handshake(mode)
+
+	if !stopHandshake() {
+		_ = peer.Close()
+
+		return nil, fmt.Errorf("the handshake was abandoned: %w", ctx.Err())
+	}
+
+	if err != nil {
+		_ = peer.Close()
+
+		return nil, err
+	}
+
+	return peer, nil
+}
+
+func (p *Peer) Close() error {
+	return p.conn.Close()
+}
+
+// Handshake exchanges hello messages with the peer and checks that both
+// peers speak the same protocol version and want to do the same thing.
+func (p *Peer) Handshake(mode Mode) error {
+	var peerHello Hello
+
+	if err := p.exchange(MessageHello, Hello{Mode: mode}, &peerHello); err != nil {
+		return fmt.Errorf("unable to complete the handshake: %w", err)
+	}
+
+	if peerHello.Mode != mode {
+		return fmt.Errorf("%w: want %s, got %s", ErrModeMismatch, mode, peerHello.Mode)
+	}
+
+	return nil
+}
+
+// SendError reports an error to the peer.
+func (p *Peer) SendError(err error) error {
+	return p.send(MessageError, ErrorMessage{Message: err.Error()})
+}
+
+// exchange sends a message to the peer and receives the peer's message of the
+// same type in return. The guest sends first and the host receives first.
+func (p *Peer) exchange(msgType MessageType, payload, reply any) error {
+	if p.role == RoleGuest {
+		if err := p.send(msgType, payload); err != nil {
+			return err
+		}
+
+		return p.receive(msgType, reply)
+	}
+
+	if err := p.receive(msgType, reply); err != nil {
+		return err
+	}
+
+	return p.send(msgType, payload)
+}
+
+func (p *Peer) send(msgType MessageType, payload any) error {
+	msg, err := newMessage(msgType, payload)
+	if err != nil {
+		return err
+	}
+
+	if err := p.conn.SetWriteDeadline(time.Now().Add(p.timeout)); err != nil {
+		return fmt.Errorf("unable to set the write deadline: %w", err)
+	}
+
+	if err := p.encoder.Encode(msg); err != nil {
+		return fmt.Errorf("unable to send the %s message: %w", msgType, err)
+	}
+
+	return nil
+}
+
+func (p *Peer) receive(msgType MessageType, payload any) error {
+	if err := p.conn.SetReadDeadline(time.Now().Add(p.timeout)); err != nil {
+		return fmt.Errorf("unable to set the read deadline: %w", err)
+	}
+
+	if !p.scanner.Scan() {
+		if err := p.scanner.Err(); err != nil {
+			return fmt.Errorf("unable to receive the %s message: %w", msgType, err)
+		}
+
+		return fmt.Errorf("unable to receive the %s message: the connection was closed", msgType)
+	}
+
+	var msg Message
+
+	if err := json.Unmarshal(p.scanner.Bytes(), &msg); err != nil {
+		return fmt.Errorf("unable to decode the message from the peer: %w", err)
+	}
+
+	if msg.Version != ProtocolVersion {
+		return fmt.Errorf("%w: want %d, got %d", ErrVersionMismatch, ProtocolVersion, msg.Version)
+	}
+
+	if msg.Type == MessageError {
+		var errMsg ErrorMessage
+
+		if err := json.Unmarshal(msg.Payload, &errMsg); err != nil {
+			return fmt.Errorf("unable to decode the error from the peer: %w", err)
+		}
+
+		return fmt.Errorf("%w: %s", ErrPeerError, errMsg.Message)
+	}
+
+	if msg.Type != msgType {
+		return fmt.Errorf("%w: want %s, got %s", ErrUnexpectedReply, msgType, msg.Type)
+	}
+
+	if err := json.Unmarshal(msg.Payload, payload); err != nil {
+		return fmt.Errorf("unable to decode the %s payload: %w", msgType, err)
+	}
+
+	return nil
+}
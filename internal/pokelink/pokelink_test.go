@@ -0,0 +1,245 @@
+package pokelink_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokelink"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrade"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+const testTimeout = 5 * time.Second
+
+type hostResult struct {
+	peer *pokelink.Peer
+	err  error
+}
+
+// connect starts a host and a guest in-process and returns both connected peers.
+func connect(t *testing.T, hostMode, guestMode pokelink.Mode) (*pokelink.Peer, *pokelink.Peer, error, error) {
+	t.Helper()
+
+	listener, err := pokelink.Listen("127.0.0.1:0", testTimeout)
+	if err != nil {
+		t.Fatalf("Unable to start the listener: %v", err)
+	}
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	hostChan := make(chan hostResult)
+
+	go func() {
+		peer, err := listener.Accept(hostMode)
+		hostChan <- hostResult{peer: peer, err: err}
+	}()
+
+	guest, guestErr := pokelink.Join(context.Background(), listener.Addr().String(), guestMode, testTimeout)
+
+	host := <-hostChan
+
+	for _, peer := range []*pokelink.Peer{host.peer, guest} {
+		if peer != nil {
+			t.Cleanup(func() { _ = peer.Close() })
+		}
+	}
+
+	return host.peer, guest, host.err, guestErr
+}
+
+func testPokemon(name string, hp, attack, defense, speed int) pokeapi.Pokemon {
+	stat := func(statName string, value int) pokeapi.PokemonStat {
+		return pokeapi.PokemonStat{Stat: pokeapi.NamedAPIResource{Name: statName}, BaseStat: value}
+	}
+
+	return pokeapi.Pokemon{
+		ID:   1,
+		Name: name,
+		Stats: []pokeapi.PokemonStat{
+			stat("hp", hp),
+			stat("attack", attack),
+			stat("defense", defense),
+			stat("special-attack", attack),
+			stat("special-defense", defense),
+			stat("speed", speed),
+		},
+	}
+}
+
+func tradeOffer(name string, pokemon pokeapi.Pokemon) poketrade.TradedPokemon {
+	return poketrade.TradedPokemon{
+		Name:       name,
+		Pokemon:    pokemon,
+		Individual: nil,
+		HeldItem:   "",
+	}
+}
+
+func TestTrade(t *testing.T) {
+	host, guest, hostErr, guestErr := connect(t, pokelink.ModeTrade, pokelink.ModeTrade)
+	if hostErr != nil || guestErr != nil {
+		t.Fatalf("Unable to connect the peers: host error: %v, guest error: %v", hostErr, guestErr)
+	}
+
+	type tradeResult struct {
+		received poketrade.TradedPokemon
+		err      error
+	}
+
+	hostChan := make(chan tradeResult)
+
+	pikachu := tradeOffer("pikachu", testPokemon("pikachu", 35, 55, 40, 90))
+	pikachu.HeldItem = "light-ball"
+	pikachu.Individual = &poketrainer.Individual{
+		Gender: poketrainer.GenderFemale,
+		IVs:    map[string]int{"hp": 31, "speed": 12},
+		Moves:  []string{"thunder-shock"},
+	}
+
+	go func() {
+		received, err := host.Trade(pikachu, nil)
+		hostChan <- tradeResult{received: received, err: err}
+	}()
+
+	guestReceived, err := guest.Trade(tradeOffer("eevee", testPokemon("eevee", 55, 55, 50, 55)), nil)
+	if err != nil {
+		t.Fatalf("The guest was unable to trade: %v", err)
+	}
+
+	hostReceived := <-hostChan
+	if hostReceived.err != nil {
+		t.Fatalf("The host was unable to trade: %v", hostReceived.err)
+	}
+
+	if guestReceived.Name != "pikachu" {
+		t.Errorf("Unexpected Pokemon received by the guest: want pikachu, got %s", guestReceived.Name)
+	}
+
+	if guestReceived.HeldItem != "light-ball" {
+		t.Errorf("Unexpected held item received by the guest: want light-ball, got %q", guestReceived.HeldItem)
+	}
+
+	if guestReceived.Individual == nil || guestReceived.Individual.IVs["hp"] != 31 {
+		t.Errorf("Unexpected individual traits received by the guest: want %+v, got %+v", pikachu.Individual, guestReceived.Individual)
+	}
+
+	if hostReceived.received.Name != "eevee" {
+		t.Errorf("Unexpected Pokemon received by the host: want eevee, got %s", hostReceived.received.Name)
+	}
+
+	if hostReceived.received.Individual != nil || hostReceived.received.HeldItem != "" {
+		t.Errorf("Unexpected traits received by the host: got %+v", hostReceived.received)
+	}
+}
+
+func TestTradeRejected(t *testing.T) {
+	host, guest, hostErr, guestErr := connect(t, pokelink.ModeTrade, pokelink.ModeTrade)
+	if hostErr != nil || guestErr != nil {
+		t.Fatalf("Unable to connect the peers: host error: %v, guest error: %v", hostErr, guestErr)
+	}
+
+	hostChan := make(chan error)
+
+	go func() {
+		_, err := host.Trade(tradeOffer("pikachu", testPokemon("pikachu", 35, 55, 40, 90)), func(poketrade.TradedPokemon) error {
+			return errors.New("you already have an eevee")
+		})
+		hostChan <- err
+	}()
+
+	_, err := guest.Trade(tradeOffer("eevee", testPokemon("eevee", 55, 55, 50, 55)), nil)
+	if !errors.Is(err, pokelink.ErrTradeRejected) {
+		t.Errorf("Unexpected error from the guest: want %v, got %v", pokelink.ErrTradeRejected, err)
+	}
+
+	if err := <-hostChan; !errors.Is(err, pokelink.ErrTradeRejected) {
+		t.Errorf("Unexpected error from the host: want %v, got %v", pokelink.ErrTradeRejected, err)
+	}
+}
+
+func TestBattle(t *testing.T) {
+	host, guest, hostErr, guestErr := connect(t, pokelink.ModeBattle, pokelink.ModeBattle)
+	if hostErr != nil || guestErr != nil {
+		t.Fatalf("Unable to connect the peers: host error: %v, guest error: %v", hostErr, guestErr)
+	}
+
+	type battleResult struct {
+		result pokelink.BattleResult
+		err    error
+	}
+
+	hostChan := make(chan battleResult)
+
+	go func() {
+		result, err := host.Battle("magikarp", testPokemon("magikarp", 20, 10, 55, 80))
+		hostChan <- battleResult{result: result, err: err}
+	}()
+
+	guestResult, err := guest.Battle("mewtwo", testPokemon("mewtwo", 106, 110, 90, 130))
+	if err != nil {
+		t.Fatalf("The guest was unable to battle: %v", err)
+	}
+
+	hostResult := <-hostChan
+	if hostResult.err != nil {
+		t.Fatalf("The host was unable to battle: %v", hostResult.err)
+	}
+
+	if !guestResult.Won || hostResult.result.Won {
+		t.Errorf("Unexpected battle outcome: want the guest to win, got host won: %t, guest won: %t", hostResult.result.Won, guestResult.Won)
+	}
+
+	if hostResult.result.Turns != guestResult.Turns {
+		t.Errorf("The peers disagree on the number of turns: host %d, guest %d", hostResult.result.Turns, guestResult.Turns)
+	}
+}
+
+func TestModeMismatch(t *testing.T) {
+	_, _, hostErr, guestErr := connect(t, pokelink.ModeTrade, pokelink.ModeBattle)
+
+	if !errors.Is(hostErr, pokelink.ErrModeMismatch) {
+		t.Errorf("Unexpected error from the host: want %v, got %v", pokelink.ErrModeMismatch, hostErr)
+	}
+
+	if !errors.Is(guestErr, pokelink.ErrModeMismatch) {
+		t.Errorf("Unexpected error from the guest: want %v, got %v", pokelink.ErrModeMismatch, guestErr)
+	}
+}
+
+func TestJoinCancelled(t *testing.T) {
+	// The silent host accepts the connection but never replies to the handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to start the listener: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err = pokelink.Join(ctx, listener.Addr().String(), pokelink.ModeTrade, testTimeout)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error: want %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("The cancelled join took too long to return: %v", elapsed)
+	}
+}
@@ -0,0 +1,91 @@
+package pokelink
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrade"
+)
+
+// ProtocolVersion is the version of the message protocol spoken between two peers.
+// Peers using different versions of the protocol refuse to connect to each other.
+const ProtocolVersion int = 1
+
+type MessageType string
+
+const (
+	MessageHello        MessageType = "hello"
+	MessageError        MessageType = "error"
+	MessageTradeOffer   MessageType = "trade-offer"
+	MessageTradeAccept  MessageType = "trade-accept"
+	MessageBattleOffer  MessageType = "battle-offer"
+	MessageBattleResult MessageType = "battle-result"
+)
+
+type Mode string
+
+const (
+	ModeTrade  Mode = "trade"
+	ModeBattle Mode = "battle"
+)
+
+var (
+	ErrVersionMismatch = errors.New("the peer is using a different protocol version")
+	ErrModeMismatch    = errors.New("the peer wants to do something different")
+	ErrPeerError       = errors.New("the peer reported an error")
+	ErrUnexpectedReply = errors.New("unexpected message from the peer")
+)
+
+// Message is the envelope for every message sent between two peers.
+// Messages are encoded as JSON, one per line.
+type Message struct {
+	Version int             `json:"version"`
+	Type    MessageType     `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type Hello struct {
+	Mode Mode `json:"mode"`
+}
+
+type ErrorMessage struct {
+	Message string `json:"message"`
+}
+
+type TradeOffer struct {
+	Package poketrade.Package `json:"package"`
+}
+
+type TradeAccept struct {
+	Accepted bool   `json:"accepted"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type BattleOffer struct {
+	Package poketrade.Package `json:"package"`
+
+	// Seed is the seed for the battle's random number generator.
+	// It is only set by the host.
+	Seed uint64 `json:"seed,omitempty"`
+}
+
+type BattleResultMessage struct {
+	Winner string `json:"winner"`
+	Turns  int    `json:"turns"`
+}
+
+func newMessage(msgType MessageType, payload any) (Message, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Message{}, fmt.Errorf("unable to encode the %s payload: %w", msgType, err)
+	}
+
+	msg := Message{
+		Version: ProtocolVersion,
+		Type:    msgType,
+		Payload: data,
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,56 @@
+package pokelink
+
+import (
+	"errors"
+	"fmt"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrade"
+)
+
+var ErrTradeRejected = errors.New("the trade was rejected")
+
+// Trade offers the Pokemon to the peer in exchange for the peer's Pokemon.
+// canReceive is called with the peer's Pokemon to decide whether or not it can be accepted.
+// The trade is only complete when both peers accept, in which case the peer's
+// Pokemon is returned.
+func (p *Peer) Trade(
+	offer poketrade.TradedPokemon,
+	canReceive func(traded poketrade.TradedPokemon) error,
+) (poketrade.TradedPokemon, error) {
+	pkg, err := poketrade.NewPackage(offer)
+	if err != nil {
+		return poketrade.TradedPokemon{}, fmt.Errorf("unable to package %s for trading: %w", offer.Name, err)
+	}
+
+	var peerOffer TradeOffer
+
+	if err := p.exchange(MessageTradeOffer, TradeOffer{Package: pkg}, &peerOffer); err != nil {
+		return poketrade.TradedPokemon{}, fmt.Errorf("unable to exchange trade offers: %w", err)
+	}
+
+	received, rejection := peerOffer.Package.Unpack()
+	if rejection == nil && canReceive != nil {
+		rejection = canReceive(received)
+	}
+
+	accept := TradeAccept{Accepted: rejection == nil}
+	if rejection != nil {
+		accept.Reason = rejection.Error()
+	}
+
+	var peerAccept TradeAccept
+
+	if err := p.exchange(MessageTradeAccept, accept, &peerAccept); err != nil {
+		return poketrade.TradedPokemon{}, fmt.Errorf("unable to confirm the trade: %w", err)
+	}
+
+	if rejection != nil {
+		return poketrade.TradedPokemon{}, fmt.Errorf("%w: unable to accept the peer's Pokemon: %w", ErrTradeRejected, rejection)
+	}
+
+	if !peerAccept.Accepted {
+		return poketrade.TradedPokemon{}, fmt.Errorf("%w by the peer: %s", ErrTradeRejected, peerAccept.Reason)
+	}
+
+	return received, nil
+}
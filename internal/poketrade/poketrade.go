@@ -0,0 +1,211 @@
+package poketrade
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+// FormatVersion is the version of the trade package format.
+const FormatVersion int = 3
+
+// checksumKey is the key used to compute the HMAC of the package contents.
+// The key is part of the source code and shared by every copy of pokecli, so
+// the checksum only guards against accidental corruption. Anyone who reads the
+// source can compute a valid checksum for an edited package.
+var checksumKey = []byte("pokecli trade package")
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported trade package version")
+	ErrChecksumMismatch   = errors.New("the checksum does not match the package contents")
+	ErrInvalidPokemon     = errors.New("invalid Pokemon data")
+	ErrNameMismatch       = errors.New("the name of the Pokemon does not match its data")
+)
+
+// TradedPokemon is a Pokemon from a trainer's Pokedex along with the traits
+// and the item that belong to that trainer's Pokemon.
+type TradedPokemon struct {
+	// Name is the name of the Pokemon in the trainer's Pokedex.
+	Name       string
+	Pokemon    pokeapi.Pokemon
+	Individual *poketrainer.Individual
+	HeldItem   string
+}
+
+// Package is a single Pokemon packaged up for trading between trainers.
+// The checksum is the HMAC-SHA256 of the version, the name, the encoded
+// Pokemon data, the individual traits and the held item. It is an integrity
+// check against accidental corruption, not a protection against forgery.
+type Package struct {
+	Version     int                     `json:"version"`
+	PokemonName string                  `json:"pokemonName"`
+	Pokemon     json.RawMessage         `json:"pokemon"`
+	Individual  *poketrainer.Individual `json:"individual,omitempty"`
+	HeldItem    string                  `json:"heldItem,omitempty"`
+	Checksum    string                  `json:"checksum"`
+}
+
+// NewPackage packages the Pokemon for trading.
+func NewPackage(traded TradedPokemon) (Package, error) {
+	data, err := json.Marshal(traded.Pokemon)
+	if err != nil {
+		return Package{}, fmt.Errorf("unable to encode the Pokemon data: %w", err)
+	}
+
+	pkg := Package{
+		Version:     FormatVersion,
+		PokemonName: traded.Name,
+		Pokemon:     data,
+		Individual:  traded.Individual,
+		HeldItem:    traded.HeldItem,
+		Checksum:    "",
+	}
+
+	sum, err := pkg.checksum()
+	if err != nil {
+		return Package{}, err
+	}
+
+	pkg.Checksum = sum
+
+	return pkg, nil
+}
+
+// Unpack validates the package and returns the Pokemon inside.
+func (p Package) Unpack() (TradedPokemon, error) {
+	if p.Version != FormatVersion {
+		return TradedPokemon{}, fmt.Errorf("%w: want %d, got %d", ErrUnsupportedVersion, FormatVersion, p.Version)
+	}
+
+	sum, err := p.checksum()
+	if err != nil {
+		return TradedPokemon{}, fmt.Errorf("%w: %w", ErrInvalidPokemon, err)
+	}
+
+	if !hmac.Equal([]byte(sum), []byte(p.Checksum)) {
+		return TradedPokemon{}, ErrChecksumMismatch
+	}
+
+	var pokemon pokeapi.Pokemon
+
+	if err := json.Unmarshal(p.Pokemon, &pokemon); err != nil {
+		return TradedPokemon{}, fmt.Errorf("%w: %w", ErrInvalidPokemon, err)
+	}
+
+	if p.PokemonName == "" {
+		return TradedPokemon{}, fmt.Errorf("%w: the name of the Pokemon is missing", ErrInvalidPokemon)
+	}
+
+	if pokemon.ID <= 0 || pokemon.Name == "" {
+		return TradedPokemon{}, fmt.Errorf("%w: the Pokemon's ID or name is missing", ErrInvalidPokemon)
+	}
+
+	if !matchesPokemon(p.PokemonName, pokemon.Name) {
+		return TradedPokemon{}, fmt.Errorf("%w: %s is not a %s", ErrNameMismatch, p.PokemonName, pokemon.Name)
+	}
+
+	if p.Individual != nil {
+		if err := p.Individual.Validate(); err != nil {
+			return TradedPokemon{}, fmt.Errorf("%w: %w", ErrInvalidPokemon, err)
+		}
+	}
+
+	traded := TradedPokemon{
+		Name:       p.PokemonName,
+		Pokemon:    pokemon,
+		Individual: p.Individual,
+		HeldItem:   p.HeldItem,
+	}
+
+	return traded, nil
+}
+
+// Write encodes the package to the writer.
+func Write(writer io.Writer, pkg Package) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(pkg); err != nil {
+		return fmt.Errorf("unable to encode the trade package: %w", err)
+	}
+
+	return nil
+}
+
+// Read decodes a package from the reader. The package is not validated
+// until it is unpacked.
+func Read(reader io.Reader) (Package, error) {
+	var pkg Package
+
+	if err := json.NewDecoder(reader).Decode(&pkg); err != nil {
+		return Package{}, fmt.Errorf("unable to decode the trade package: %w", err)
+	}
+
+	return pkg, nil
+}
+
+// checksum returns the HMAC-SHA256 of the package's version, Pokemon name,
+// compacted Pokemon data, individual traits and held item. Each field is
+// prefixed with its length so that the boundaries between the fields cannot
+// be moved without changing the checksum, and the data is compacted so that
+// the checksum is not affected by any indentation.
+func (p Package) checksum() (string, error) {
+	var compacted bytes.Buffer
+
+	if err := json.Compact(&compacted, p.Pokemon); err != nil {
+		return "", fmt.Errorf("unable to compact the JSON data: %w", err)
+	}
+
+	// The individual traits are decoded with the rest of the package so
+	// they are encoded again to get the same bytes regardless of the layout.
+	individual, err := json.Marshal(p.Individual)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode the individual traits: %w", err)
+	}
+
+	fields := [][]byte{
+		[]byte(strconv.Itoa(p.Version)),
+		[]byte(p.PokemonName),
+		compacted.Bytes(),
+		individual,
+		[]byte(p.HeldItem),
+	}
+
+	mac := hmac.New(sha256.New, checksumKey)
+
+	for _, field := range slices.All(fields) {
+		_ = binary.Write(mac, binary.BigEndian, uint64(len(field)))
+		_, _ = mac.Write(field)
+	}
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// matchesPokemon returns true if the name in the package is the name of the
+// Pokemon or the name given to a duplicate of it in the Pokedex (e.g. pikachu-2).
+func matchesPokemon(name, pokemonName string) bool {
+	if name == pokemonName {
+		return true
+	}
+
+	suffix, ok := strings.CutPrefix(name, pokemonName+"-")
+	if !ok {
+		return false
+	}
+
+	num, err := strconv.Atoi(suffix)
+
+	return err == nil && num >= 2 && strconv.Itoa(num) == suffix
+}
@@ -0,0 +1,291 @@
+package poketrade_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrade"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func TestPackageRoundTrip(t *testing.T) {
+	pokemon := pokeapi.Pokemon{ID: 129, Name: "magikarp", BaseExperience: 40}
+	individual := poketrainer.Individual{
+		Gender: poketrainer.GenderMale,
+		IVs:    map[string]int{"hp": 31, "attack": 4, "speed": 27},
+		Moves:  []string{"splash", "tackle"},
+	}
+
+	sender := poketrainer.NewTrainer()
+
+	if err := sender.ReceiveTradedPokemon("magikarp", pokemon, &individual, "mystic-water"); err != nil {
+		t.Fatalf("Unable to add magikarp to the sender's Pokedex: %v", err)
+	}
+
+	sentIndividual, _ := sender.Individual("magikarp")
+	sentHeldItem, _ := sender.HeldItem("magikarp")
+
+	pkg, err := poketrade.NewPackage(poketrade.TradedPokemon{
+		Name:       "magikarp",
+		Pokemon:    pokemon,
+		Individual: &sentIndividual,
+		HeldItem:   sentHeldItem,
+	})
+	if err != nil {
+		t.Fatalf("Unable to create the trade package: %v", err)
+	}
+
+	var buffer bytes.Buffer
+
+	if err := poketrade.Write(&buffer, pkg); err != nil {
+		t.Fatalf("Unable to write the trade package: %v", err)
+	}
+
+	read, err := poketrade.Read(&buffer)
+	if err != nil {
+		t.Fatalf("Unable to read the trade package: %v", err)
+	}
+
+	got, err := read.Unpack()
+	if err != nil {
+		t.Fatalf("Unable to unpack the trade package: %v", err)
+	}
+
+	if got.Name != "magikarp" {
+		t.Errorf("Unexpected Pokemon name: want magikarp, got %s", got.Name)
+	}
+
+	if got.Pokemon.ID != pokemon.ID || got.Pokemon.BaseExperience != pokemon.BaseExperience {
+		t.Errorf("Unexpected Pokemon details: want %+v, got %+v", pokemon, got.Pokemon)
+	}
+
+	receiver := poketrainer.NewTrainer()
+
+	if err := receiver.ReceiveTradedPokemon(got.Name, got.Pokemon, got.Individual, got.HeldItem); err != nil {
+		t.Fatalf("Unable to receive magikarp: %v", err)
+	}
+
+	if heldItem, _ := receiver.HeldItem("magikarp"); heldItem != "mystic-water" {
+		t.Errorf("Unexpected held item after the trade: want mystic-water, got %q", heldItem)
+	}
+
+	receivedIndividual, ok := receiver.Individual("magikarp")
+	if !ok {
+		t.Fatal("The individual traits of magikarp were lost in the trade")
+	}
+
+	if !reflect.DeepEqual(receivedIndividual, individual) {
+		t.Errorf("Unexpected individual traits after the trade: want %+v, got %+v", individual, receivedIndividual)
+	}
+}
+
+func TestTamperedPackage(t *testing.T) {
+	pkg, err := poketrade.NewPackage(poketrade.TradedPokemon{
+		Name:       "magikarp",
+		Pokemon:    pokeapi.Pokemon{ID: 129, Name: "magikarp", BaseExperience: 40},
+		Individual: &poketrainer.Individual{Gender: poketrainer.GenderMale, IVs: map[string]int{"hp": 4}, Moves: nil},
+		HeldItem:   "mystic-water",
+	})
+	if err != nil {
+		t.Fatalf("Unable to create the trade package: %v", err)
+	}
+
+	var buffer bytes.Buffer
+
+	if err := poketrade.Write(&buffer, pkg); err != nil {
+		t.Fatalf("Unable to write the trade package: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		old  string
+		new  string
+		want error
+	}{
+		{
+			name: "Tampered Pokemon data",
+			old:  `"base_experience": 40`,
+			new:  `"base_experience": 400`,
+			want: poketrade.ErrChecksumMismatch,
+		},
+		{
+			name: "Tampered Pokemon name",
+			old:  `"pokemonName": "magikarp"`,
+			new:  `"pokemonName": "magikarp-2"`,
+			want: poketrade.ErrChecksumMismatch,
+		},
+		{
+			name: "Tampered IVs",
+			old:  `"hp": 4`,
+			new:  `"hp": 31`,
+			want: poketrade.ErrChecksumMismatch,
+		},
+		{
+			name: "Tampered held item",
+			old:  `"heldItem": "mystic-water"`,
+			new:  `"heldItem": "master-ball"`,
+			want: poketrade.ErrChecksumMismatch,
+		},
+		{
+			name: "Tampered version",
+			old:  fmt.Sprintf(`"version": %d`, poketrade.FormatVersion),
+			new:  `"version": 1`,
+			want: poketrade.ErrUnsupportedVersion,
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			if !strings.Contains(buffer.String(), testcase.old) {
+				t.Fatalf("The trade package does not contain %s", testcase.old)
+			}
+
+			tampered := strings.Replace(buffer.String(), testcase.old, testcase.new, 1)
+
+			read, err := poketrade.Read(strings.NewReader(tampered))
+			if err != nil {
+				t.Fatalf("Unable to read the trade package: %v", err)
+			}
+
+			if _, err := read.Unpack(); !errors.Is(err, testcase.want) {
+				t.Errorf("Unexpected error after unpacking a tampered package: want %v, got %v", testcase.want, err)
+			}
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	pokemon := pokeapi.Pokemon{ID: 129, Name: "magikarp", BaseExperience: 40}
+
+	cases := []struct {
+		name string
+		want error
+	}{
+		{name: "magikarp", want: nil},
+		{name: "magikarp-2", want: nil},
+		{name: "magikarp-12", want: nil},
+		{name: "gyarados", want: poketrade.ErrNameMismatch},
+		{name: "magikarp-1", want: poketrade.ErrNameMismatch},
+		{name: "magikarp-02", want: poketrade.ErrNameMismatch},
+		{name: "magikarp-shiny", want: poketrade.ErrNameMismatch},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			pkg, err := poketrade.NewPackage(poketrade.TradedPokemon{
+				Name:       testcase.name,
+				Pokemon:    pokemon,
+				Individual: nil,
+				HeldItem:   "",
+			})
+			if err != nil {
+				t.Fatalf("Unable to create the trade package: %v", err)
+			}
+
+			if _, err := pkg.Unpack(); !errors.Is(err, testcase.want) {
+				t.Errorf("Unexpected error after unpacking the package: want %v, got %v", testcase.want, err)
+			}
+		})
+	}
+}
+
+func TestPackageIndividual(t *testing.T) {
+	pokemon := pokeapi.Pokemon{ID: 129, Name: "magikarp", BaseExperience: 40}
+
+	cases := []struct {
+		name       string
+		individual poketrainer.Individual
+		want       error
+	}{
+		{
+			name: "Valid traits",
+			individual: poketrainer.Individual{
+				Gender: poketrainer.GenderFemale,
+				IVs:    map[string]int{"hp": 0, "speed": 31},
+				Moves:  []string{"splash", "tackle", "flail", "bounce"},
+			},
+			want: nil,
+		},
+		{
+			name: "IV above the maximum",
+			individual: poketrainer.Individual{
+				Gender: poketrainer.GenderFemale,
+				IVs:    map[string]int{"hp": 32},
+				Moves:  nil,
+			},
+			want: poketrade.ErrInvalidPokemon,
+		},
+		{
+			name: "Negative IV",
+			individual: poketrainer.Individual{
+				Gender: poketrainer.GenderMale,
+				IVs:    map[string]int{"attack": -1},
+				Moves:  nil,
+			},
+			want: poketrade.ErrInvalidPokemon,
+		},
+		{
+			name: "Too many moves",
+			individual: poketrainer.Individual{
+				Gender: poketrainer.GenderMale,
+				IVs:    nil,
+				Moves:  []string{"splash", "tackle", "flail", "bounce", "hydro-pump"},
+			},
+			want: poketrade.ErrInvalidPokemon,
+		},
+		{
+			name: "Unknown gender",
+			individual: poketrainer.Individual{
+				Gender: "unknown",
+				IVs:    nil,
+				Moves:  nil,
+			},
+			want: poketrade.ErrInvalidPokemon,
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			pkg, err := poketrade.NewPackage(poketrade.TradedPokemon{
+				Name:       "magikarp",
+				Pokemon:    pokemon,
+				Individual: &testcase.individual,
+				HeldItem:   "",
+			})
+			if err != nil {
+				t.Fatalf("Unable to create the trade package: %v", err)
+			}
+
+			if _, err := pkg.Unpack(); !errors.Is(err, testcase.want) {
+				t.Errorf("Unexpected error after unpacking the package: want %v, got %v", testcase.want, err)
+			}
+		})
+	}
+}
+
+func TestOlderPackageVersion(t *testing.T) {
+	// The package was exported before the held item and the individual
+	// traits were added to the trade package and its checksum.
+	file, err := os.Open(filepath.Join("testdata", "magikarp-v2.json"))
+	if err != nil {
+		t.Fatalf("Unable to open the trade package: %v", err)
+	}
+	defer file.Close()
+
+	pkg, err := poketrade.Read(file)
+	if err != nil {
+		t.Fatalf("Unable to read the trade package: %v", err)
+	}
+
+	if _, err := pkg.Unpack(); !errors.Is(err, poketrade.ErrUnsupportedVersion) {
+		t.Errorf("Unexpected error after unpacking an older package: want %v, got %v", poketrade.ErrUnsupportedVersion, err)
+	}
+}
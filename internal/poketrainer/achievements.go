@@ -0,0 +1,136 @@
+package poketrainer
+
+import "slices"
+
+// Achievement is a badge that is unlocked when the trainer reaches a milestone.
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+type achievementDefinition struct {
+	achievement Achievement
+	unlocked    func(statistics) bool
+}
+
+var achievementDefinitions = []achievementDefinition{
+	{
+		achievement: Achievement{
+			ID:          "first-catch",
+			Name:        "First Catch",
+			Description: "Catch your first Pokemon",
+		},
+		unlocked: func(s statistics) bool {
+			return s.catches >= 1
+		},
+	},
+	{
+		achievement: Achievement{
+			ID:          "collector",
+			Name:        "Collector",
+			Description: "Catch 10 different species of Pokemon",
+		},
+		unlocked: func(s statistics) bool {
+			return len(s.speciesCaught) >= 10
+		},
+	},
+	{
+		achievement: Achievement{
+			ID:          "master-collector",
+			Name:        "Master Collector",
+			Description: "Catch 50 different species of Pokemon",
+		},
+		unlocked: func(s statistics) bool {
+			return len(s.speciesCaught) >= 50
+		},
+	},
+	{
+		achievement: Achievement{
+			ID:          "explorer",
+			Name:        "Explorer",
+			Description: "Visit 10 different location areas",
+		},
+		unlocked: func(s statistics) bool {
+			return len(s.areasVisited) >= 10
+		},
+	},
+	{
+		achievement: Achievement{
+			ID:          "never-give-up",
+			Name:        "Never Give Up",
+			Description: "Have 10 Pokemon escape from your Pokeballs",
+		},
+		unlocked: func(s statistics) bool {
+			return s.escapes >= 10
+		},
+	},
+	{
+		achievement: Achievement{
+			ID:          "catch-and-release",
+			Name:        "Catch and Release",
+			Description: "Release a Pokemon back into the wild",
+		},
+		unlocked: func(s statistics) bool {
+			return s.releases >= 1
+		},
+	},
+	{
+		achievement: Achievement{
+			ID:          "area-master",
+			Name:        "Area Master",
+			Description: "Catch every Pokemon that can be found in a location area",
+		},
+		unlocked: func(s statistics) bool {
+			return len(s.completedAreas()) > 0
+		},
+	},
+	{
+		achievement: Achievement{
+			ID:          "type-master",
+			Name:        "Type Master",
+			Description: "Catch a Pokemon of every type",
+		},
+		unlocked: func(s statistics) bool {
+			return len(s.typesCollected) == len(pokemonTypes)
+		},
+	},
+}
+
+// Achievements returns all the achievements in the order that they are
+// defined along with whether each of them has been unlocked by the trainer.
+func (t *Trainer) Achievements() ([]Achievement, map[string]bool) {
+	achievements := make([]Achievement, len(achievementDefinitions))
+	unlocked := make(map[string]bool)
+
+	for ind, definition := range slices.All(achievementDefinitions) {
+		achievements[ind] = definition.achievement
+
+		_, ok := t.achievements[definition.achievement.ID]
+		unlocked[definition.achievement.ID] = ok
+	}
+
+	return achievements, unlocked
+}
+
+// NewAchievements returns the achievements that have been unlocked since
+// the last time this method was called.
+func (t *Trainer) NewAchievements() []Achievement {
+	newAchievements := t.newAchievements
+	t.newAchievements = nil
+
+	return newAchievements
+}
+
+func (t *Trainer) checkAchievements() {
+	for _, definition := range slices.All(achievementDefinitions) {
+		if _, ok := t.achievements[definition.achievement.ID]; ok {
+			continue
+		}
+
+		if definition.unlocked(t.stats) {
+			t.achievements[definition.achievement.ID] = struct{}{}
+			t.newAchievements = append(t.newAchievements, definition.achievement)
+		}
+	}
+}
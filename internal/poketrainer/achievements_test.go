@@ -0,0 +1,178 @@
+package poketrainer_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func TestAchievements(t *testing.T) {
+	area := pokeapi.LocationArea{
+		Name: "lake-verity-before-galactic-intervention",
+		PokemonEncounters: []pokeapi.PokemonEncounter{
+			{Pokemon: pokeapi.NamedAPIResource{Name: "psyduck"}},
+			{Pokemon: pokeapi.NamedAPIResource{Name: "golduck"}},
+		},
+	}
+
+	allTypes := []string{
+		"normal", "fire", "water", "grass", "electric", "ice",
+		"fighting", "poison", "ground", "flying", "psychic", "bug",
+		"rock", "ghost", "dragon", "dark", "steel", "fairy",
+	}
+
+	catchEveryType := make([]func(*poketrainer.Trainer) error, 0)
+
+	for ind := 0; ind < len(allTypes); ind += 2 {
+		name := fmt.Sprintf("pokemon-%d", ind)
+		catchEveryType = append(catchEveryType, catch(name, pokemonOfTypes(name, allTypes[ind], allTypes[ind+1])))
+	}
+
+	cases := []struct {
+		name  string
+		steps []func(*poketrainer.Trainer) error
+		want  []string
+	}{
+		{
+			name:  "New trainer",
+			steps: nil,
+			want:  []string{},
+		},
+		{
+			name: "First catch",
+			steps: []func(*poketrainer.Trainer) error{
+				catch("psyduck", pokemonOfTypes("psyduck", "water")),
+			},
+			want: []string{"first-catch"},
+		},
+		{
+			name: "An escape is not a catch",
+			steps: []func(*poketrainer.Trainer) error{
+				escape("psyduck"),
+			},
+			want: []string{},
+		},
+		{
+			name: "Area master",
+			steps: []func(*poketrainer.Trainer) error{
+				visit(area),
+				catch("psyduck", pokemonOfTypes("psyduck", "water")),
+				catch("golduck", pokemonOfTypes("golduck", "water")),
+			},
+			want: []string{"first-catch", "area-master"},
+		},
+		{
+			name: "Catching part of an area is not enough for area master",
+			steps: []func(*poketrainer.Trainer) error{
+				visit(area),
+				catch("psyduck", pokemonOfTypes("psyduck", "water")),
+				escape("golduck"),
+			},
+			want: []string{"first-catch"},
+		},
+		{
+			name: "Catches outside of the area do not count towards area master",
+			steps: []func(*poketrainer.Trainer) error{
+				catch("golduck", pokemonOfTypes("golduck", "water")),
+				visit(area),
+				catch("psyduck", pokemonOfTypes("psyduck", "water")),
+			},
+			want: []string{"first-catch"},
+		},
+		{
+			name:  "Type master",
+			steps: catchEveryType,
+			want:  []string{"first-catch", "type-master"},
+		},
+		{
+			name:  "Missing a single type is not enough for type master",
+			steps: catchEveryType[:len(catchEveryType)-1],
+			want:  []string{"first-catch"},
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			trainer := poketrainer.NewTrainer()
+
+			for _, step := range slices.All(testcase.steps) {
+				if err := step(trainer); err != nil {
+					t.Fatalf("Unable to update the trainer: %v", err)
+				}
+			}
+
+			if got := unlockedAchievements(trainer); !slices.Equal(got, testcase.want) {
+				t.Errorf("Unexpected unlocked achievements: want %v, got %v", testcase.want, got)
+			}
+		})
+	}
+}
+
+func TestUndoCatchDoesNotUnlockAchievementsAgain(t *testing.T) {
+	trainer := poketrainer.NewTrainer()
+
+	if err := trainer.AddPokemonToPokedex("bidoof", pokemonOfTypes("bidoof", "normal")); err != nil {
+		t.Fatalf("Unable to add bidoof to the Pokedex: %v", err)
+	}
+
+	if got := achievementIDs(trainer.NewAchievements()); !slices.Equal(got, []string{"first-catch"}) {
+		t.Fatalf("Unexpected new achievements after the first catch: want [first-catch], got %v", got)
+	}
+
+	steps := []func(*poketrainer.Trainer) error{
+		undo,
+		redo,
+		undo,
+		catch("bidoof", pokemonOfTypes("bidoof", "normal")),
+	}
+
+	for _, step := range slices.All(steps) {
+		if err := step(trainer); err != nil {
+			t.Fatalf("Unable to update the trainer: %v", err)
+		}
+
+		if got := trainer.NewAchievements(); len(got) != 0 {
+			t.Errorf("Unexpected new achievements: want none, got %v", achievementIDs(got))
+		}
+	}
+
+	if got := unlockedAchievements(trainer); !slices.Equal(got, []string{"first-catch"}) {
+		t.Errorf("Unexpected unlocked achievements: want [first-catch], got %v", got)
+	}
+
+	stats := trainer.Statistics()
+
+	if stats.Catches != 2 {
+		t.Errorf("Unexpected number of catches: want 2, got %d", stats.Catches)
+	}
+
+	if stats.SpeciesCaught != 1 {
+		t.Errorf("Unexpected number of species caught: want 1, got %d", stats.SpeciesCaught)
+	}
+}
+
+func unlockedAchievements(trainer *poketrainer.Trainer) []string {
+	achievements, unlocked := trainer.Achievements()
+	ids := make([]string, 0)
+
+	for _, achievement := range slices.All(achievements) {
+		if unlocked[achievement.ID] {
+			ids = append(ids, achievement.ID)
+		}
+	}
+
+	return ids
+}
+
+func achievementIDs(achievements []poketrainer.Achievement) []string {
+	ids := make([]string, len(achievements))
+
+	for ind, achievement := range slices.All(achievements) {
+		ids[ind] = achievement.ID
+	}
+
+	return ids
+}
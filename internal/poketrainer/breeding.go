@@ -0,0 +1,258 @@
+package poketrainer
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+)
+
+type Gender string
+
+const (
+	GenderMale       Gender = "male"
+	GenderFemale     Gender = "female"
+	GenderGenderless Gender = "genderless"
+)
+
+const (
+	maxIV           = 31
+	inheritedIVs    = 3
+	maxMoves        = 4
+	wildMoveLevel   = 50
+	eggGroupDitto   = "ditto"
+	eggGroupNoEggs  = "no-eggs"
+	moveMethodEgg   = "egg"
+	moveMethodLevel = "level-up"
+)
+
+// Individual is the set of traits that are unique to an individual Pokemon.
+type Individual struct {
+	Gender Gender         `json:"gender"`
+	IVs    map[string]int `json:"ivs"`
+	Moves  []string       `json:"moves"`
+}
+
+// Validate returns an error if the traits could not belong to a real Pokemon,
+// e.g. if an IV is out of range or the Pokemon knows too many moves.
+func (i Individual) Validate() error {
+	if !slices.Contains([]Gender{GenderMale, GenderFemale, GenderGenderless}, i.Gender) {
+		return fmt.Errorf("unknown gender %q", i.Gender)
+	}
+
+	for stat, iv := range maps.All(i.IVs) {
+		if iv < 0 || iv > maxIV {
+			return fmt.Errorf("the %s IV must be between 0 and %d, got %d", stat, maxIV, iv)
+		}
+	}
+
+	if len(i.Moves) > maxMoves {
+		return fmt.Errorf("a Pokemon can know at most %d moves, got %d", maxMoves, len(i.Moves))
+	}
+
+	return nil
+}
+
+// NewIndividual randomly generates the traits for a Pokemon.
+// The gender rate is the chance of the Pokemon being female in eighths,
+// or -1 for genderless Pokemon.
+func NewIndividual(pokemon pokeapi.Pokemon, genderRate int) Individual {
+	roller := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+
+	ivs := make(map[string]int)
+
+	for _, stat := range slices.All(pokemon.Stats) {
+		ivs[stat.Stat.Name] = roller.IntN(maxIV + 1)
+	}
+
+	return Individual{
+		Gender: randomGender(roller, genderRate),
+		IVs:    ivs,
+		Moves:  levelUpMoves(pokemon, wildMoveLevel),
+	}
+}
+
+// CanBreed returns an error if the two Pokemon cannot produce an egg together.
+// Two Pokemon can breed if they share an egg group and are of opposite genders.
+// Ditto can breed with any Pokemon except another Ditto, but Pokemon in the
+// no-eggs group cannot breed at all.
+func CanBreed(first, second DaycarePokemon) error {
+	if slices.Contains(first.EggGroups, eggGroupNoEggs) || slices.Contains(second.EggGroups, eggGroupNoEggs) {
+		return fmt.Errorf("%w: Pokemon in the %s egg group cannot breed", ErrNotCompatible, eggGroupNoEggs)
+	}
+
+	firstDitto := slices.Contains(first.EggGroups, eggGroupDitto)
+	secondDitto := slices.Contains(second.EggGroups, eggGroupDitto)
+
+	switch {
+	case firstDitto && secondDitto:
+		return fmt.Errorf("%w: two Ditto cannot breed with each other", ErrNotCompatible)
+	case firstDitto || secondDitto:
+		return nil
+	}
+
+	if first.Individual.Gender == GenderGenderless || second.Individual.Gender == GenderGenderless {
+		return fmt.Errorf("%w: genderless Pokemon can only breed with Ditto", ErrNotCompatible)
+	}
+
+	if first.Individual.Gender == second.Individual.Gender {
+		return fmt.Errorf("%w: both Pokemon are %s", ErrNotCompatible, first.Individual.Gender)
+	}
+
+	for _, group := range slices.All(first.EggGroups) {
+		if slices.Contains(second.EggGroups, group) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: the Pokemon do not share an egg group", ErrNotCompatible)
+}
+
+// EggParent returns the parent whose species the egg will be. This is the
+// female parent, or the parent that isn't a Ditto.
+func EggParent(first, second DaycarePokemon) DaycarePokemon {
+	if slices.Contains(first.EggGroups, eggGroupDitto) {
+		return second
+	}
+
+	if slices.Contains(second.EggGroups, eggGroupDitto) {
+		return first
+	}
+
+	if second.Individual.Gender == GenderFemale {
+		return second
+	}
+
+	return first
+}
+
+// InheritIndividual generates the traits for a Pokemon hatched from an egg.
+// Three IVs are inherited from a random parent and the rest are random.
+// The baby learns the egg moves that either parent knows before filling
+// the rest of its moves with its level 1 moves.
+func InheritIndividual(first, second DaycarePokemon, baby pokeapi.Pokemon, genderRate int) Individual {
+	roller := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+
+	ivs := make(map[string]int)
+	statNames := make([]string, 0, len(baby.Stats))
+
+	for _, stat := range slices.All(baby.Stats) {
+		ivs[stat.Stat.Name] = roller.IntN(maxIV + 1)
+		statNames = append(statNames, stat.Stat.Name)
+	}
+
+	roller.Shuffle(len(statNames), func(i, j int) {
+		statNames[i], statNames[j] = statNames[j], statNames[i]
+	})
+
+	for _, statName := range slices.All(statNames[:min(inheritedIVs, len(statNames))]) {
+		parent := first
+		if roller.IntN(2) == 1 {
+			parent = second
+		}
+
+		if iv, ok := parent.Individual.IVs[statName]; ok {
+			ivs[statName] = iv
+		}
+	}
+
+	moves := make([]string, 0, maxMoves)
+
+	for _, move := range slices.All(movesByMethod(baby, moveMethodEgg)) {
+		if len(moves) == maxMoves {
+			break
+		}
+
+		if slices.Contains(first.Individual.Moves, move) || slices.Contains(second.Individual.Moves, move) {
+			moves = append(moves, move)
+		}
+	}
+
+	for _, move := range slices.All(levelUpMoves(baby, 1)) {
+		if len(moves) == maxMoves {
+			break
+		}
+
+		if !slices.Contains(moves, move) {
+			moves = append(moves, move)
+		}
+	}
+
+	return Individual{
+		Gender: randomGender(roller, genderRate),
+		IVs:    ivs,
+		Moves:  moves,
+	}
+}
+
+func randomGender(roller *rand.Rand, genderRate int) Gender {
+	if genderRate < 0 {
+		return GenderGenderless
+	}
+
+	if roller.IntN(8) < genderRate {
+		return GenderFemale
+	}
+
+	return GenderMale
+}
+
+// levelUpMoves returns up to four of the most recent moves that the Pokemon
+// learns by levelling up to the specified level.
+func levelUpMoves(pokemon pokeapi.Pokemon, level int) []string {
+	type learnedMove struct {
+		name  string
+		level int
+	}
+
+	learned := make([]learnedMove, 0)
+
+	for _, move := range slices.All(pokemon.Moves) {
+		lowest := -1
+
+		for _, details := range slices.All(move.VersionGroupDetails) {
+			if details.MoveLearnMethod.Name != moveMethodLevel || details.LevelLearnedAt > level {
+				continue
+			}
+
+			if lowest < 0 || details.LevelLearnedAt < lowest {
+				lowest = details.LevelLearnedAt
+			}
+		}
+
+		if lowest >= 0 {
+			learned = append(learned, learnedMove{name: move.Move.Name, level: lowest})
+		}
+	}
+
+	slices.SortStableFunc(learned, func(a, b learnedMove) int {
+		return b.level - a.level
+	})
+
+	moves := make([]string, 0, maxMoves)
+
+	for _, move := range slices.All(learned[:min(maxMoves, len(learned))]) {
+		moves = append(moves, move.name)
+	}
+
+	return moves
+}
+
+// movesByMethod returns the names of the moves that the Pokemon learns by the specified method.
+func movesByMethod(pokemon pokeapi.Pokemon, method string) []string {
+	moves := make([]string, 0)
+
+	for _, move := range slices.All(pokemon.Moves) {
+		for _, details := range slices.All(move.VersionGroupDetails) {
+			if details.MoveLearnMethod.Name == method {
+				moves = append(moves, move.Move.Name)
+
+				break
+			}
+		}
+	}
+
+	return moves
+}
@@ -0,0 +1,257 @@
+package poketrainer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+)
+
+const (
+	daycareCapacity = 2
+	maxEggs         = 6
+
+	// EggLayingSteps is the number of steps it takes for a compatible
+	// pair of Pokemon in the daycare to produce an egg.
+	EggLayingSteps = 5
+)
+
+var (
+	ErrDaycareFull   = errors.New("the daycare is full")
+	ErrTooManyEggs   = errors.New("you cannot carry any more eggs")
+	ErrNotInDaycare  = errors.New("the Pokemon is not in the daycare")
+	ErrPokedexClash  = errors.New("a Pokemon with the same name is already in your Pokedex")
+	ErrNotCompatible = errors.New("the Pokemon in the daycare cannot produce an egg")
+)
+
+// DaycarePokemon is a Pokemon that has been left at the daycare.
+type DaycarePokemon struct {
+	Name       string          `json:"name"`
+	Pokemon    pokeapi.Pokemon `json:"pokemon"`
+	Individual Individual      `json:"individual"`
+	Species    string          `json:"species"`
+	EggGroups  []string        `json:"eggGroups"`
+	HeldItem   string          `json:"heldItem,omitempty"`
+}
+
+// Egg is an egg produced at the daycare. It hatches after the
+// trainer has taken the specified number of steps.
+type Egg struct {
+	ID             int             `json:"id"`
+	Species        string          `json:"species"`
+	Pokemon        pokeapi.Pokemon `json:"pokemon"`
+	Individual     Individual      `json:"individual"`
+	StepsRemaining int             `json:"stepsRemaining"`
+}
+
+type daycare struct {
+	pokemon       []DaycarePokemon
+	eggs          []Egg
+	stepsSinceEgg int
+	nextEggID     int
+
+	// hatched is the names of the Pokemon that hatched during the last step.
+	hatched []string
+}
+
+// Daycare returns the Pokemon that are currently at the daycare.
+func (t *Trainer) Daycare() []DaycarePokemon {
+	return slices.Clone(t.daycare.pokemon)
+}
+
+// Eggs returns the eggs that the trainer is carrying.
+func (t *Trainer) Eggs() []Egg {
+	return slices.Clone(t.daycare.eggs)
+}
+
+// StepsUntilNextEgg returns the number of steps until a compatible
+// pair of Pokemon at the daycare will produce an egg.
+func (t *Trainer) StepsUntilNextEgg() int {
+	return max(EggLayingSteps-t.daycare.stepsSinceEgg, 0)
+}
+
+// Individual returns the individual traits of a Pokemon in the
+// Pokedex if they are known.
+func (t *Trainer) Individual(name string) (Individual, bool) {
+	individual, ok := t.individuals[name]
+
+	return individual, ok
+}
+
+// DepositInDaycare moves a Pokemon from the Pokedex to the daycare.
+func (t *Trainer) DepositInDaycare(entry DaycarePokemon) error {
+	if len(t.daycare.pokemon) >= daycareCapacity {
+		return ErrDaycareFull
+	}
+
+	details, ok := t.pokedex[entry.Name]
+	if !ok {
+		return fmt.Errorf("%s is not in the Pokedex", entry.Name)
+	}
+
+	entry.Pokemon = details
+
+	if individual, ok := t.individuals[entry.Name]; ok {
+		entry.Individual = individual
+	}
+
+	entry.HeldItem = t.heldItems[entry.Name]
+
+	event := Event{
+		Kind:           EventDaycareDeposit,
+		Time:           time.Now(),
+		PokemonName:    entry.Name,
+		DaycarePokemon: &entry,
+	}
+
+	return t.record(event)
+}
+
+// WithdrawFromDaycare moves a Pokemon from the daycare back to the Pokedex.
+func (t *Trainer) WithdrawFromDaycare(name string) error {
+	if t.daycareIndex(name) < 0 {
+		return ErrNotInDaycare
+	}
+
+	if _, ok := t.pokedex[name]; ok {
+		return ErrPokedexClash
+	}
+
+	event := Event{
+		Kind:        EventDaycareWithdraw,
+		Time:        time.Now(),
+		PokemonName: name,
+	}
+
+	return t.record(event)
+}
+
+// DaycareStep records a step taken by the trainer while there are Pokemon at
+// the daycare or eggs waiting to hatch. It returns the names of any Pokemon
+// that hatched from their eggs, and whether a compatible pair of Pokemon at the
+// daycare is ready to produce an egg.
+func (t *Trainer) DaycareStep() ([]string, bool, error) {
+	if len(t.daycare.pokemon) == 0 && len(t.daycare.eggs) == 0 {
+		return nil, false, nil
+	}
+
+	if err := t.record(Event{Kind: EventDaycareStep, Time: time.Now()}); err != nil {
+		return nil, false, err
+	}
+
+	hatched := t.daycare.hatched
+
+	eggDue := len(t.daycare.pokemon) == daycareCapacity &&
+		t.daycare.stepsSinceEgg >= EggLayingSteps &&
+		len(t.daycare.eggs) < maxEggs &&
+		CanBreed(t.daycare.pokemon[0], t.daycare.pokemon[1]) == nil
+
+	return hatched, eggDue, nil
+}
+
+// LayEgg adds an egg produced at the daycare to the trainer's eggs.
+func (t *Trainer) LayEgg(egg Egg) error {
+	if len(t.daycare.eggs) >= maxEggs {
+		return ErrTooManyEggs
+	}
+
+	egg.ID = t.daycare.nextEggID
+
+	event := Event{
+		Kind: EventEggLaid,
+		Time: time.Now(),
+		Egg:  &egg,
+	}
+
+	return t.record(event)
+}
+
+func (t *Trainer) daycareIndex(name string) int {
+	return slices.IndexFunc(t.daycare.pokemon, func(entry DaycarePokemon) bool {
+		return entry.Name == name
+	})
+}
+
+// applyDaycareEvent applies the change described by the daycare event to the trainer's state.
+func (t *Trainer) applyDaycareEvent(event Event) {
+	switch event.Kind {
+	case EventDaycareDeposit:
+		if event.DaycarePokemon == nil {
+			return
+		}
+
+		delete(t.pokedex, event.PokemonName)
+		delete(t.individuals, event.PokemonName)
+		delete(t.heldItems, event.PokemonName)
+
+		t.daycare.pokemon = append(t.daycare.pokemon, *event.DaycarePokemon)
+		t.daycare.stepsSinceEgg = 0
+	case EventDaycareWithdraw:
+		ind := t.daycareIndex(event.PokemonName)
+		if ind < 0 {
+			return
+		}
+
+		entry := t.daycare.pokemon[ind]
+
+		t.pokedex[entry.Name] = entry.Pokemon
+		t.individuals[entry.Name] = entry.Individual
+
+		if entry.HeldItem != "" {
+			t.heldItems[entry.Name] = entry.HeldItem
+		}
+
+		t.daycare.pokemon = slices.Delete(t.daycare.pokemon, ind, ind+1)
+		t.daycare.stepsSinceEgg = 0
+	case EventDaycareStep:
+		t.daycare.stepsSinceEgg++
+		t.daycare.hatched = nil
+
+		remaining := make([]Egg, 0, len(t.daycare.eggs))
+
+		for _, egg := range slices.All(t.daycare.eggs) {
+			egg.StepsRemaining--
+
+			if egg.StepsRemaining > 0 {
+				remaining = append(remaining, egg)
+
+				continue
+			}
+
+			name := t.availablePokedexName(egg.Pokemon.Name)
+
+			t.pokedex[name] = egg.Pokemon
+			t.individuals[name] = egg.Individual
+			t.daycare.hatched = append(t.daycare.hatched, name)
+		}
+
+		t.daycare.eggs = remaining
+	case EventEggLaid:
+		if event.Egg == nil {
+			return
+		}
+
+		t.daycare.eggs = append(t.daycare.eggs, *event.Egg)
+		t.daycare.nextEggID = max(t.daycare.nextEggID, event.Egg.ID) + 1
+		t.daycare.stepsSinceEgg = 0
+	default:
+	}
+}
+
+// availablePokedexName returns a name for a hatched Pokemon that does
+// not clash with any of the Pokemon already in the Pokedex.
+func (t *Trainer) availablePokedexName(species string) string {
+	if _, ok := t.pokedex[species]; !ok {
+		return species
+	}
+
+	for num := 2; ; num++ {
+		name := fmt.Sprintf("%s-%d", species, num)
+
+		if _, ok := t.pokedex[name]; !ok {
+			return name
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package poketrainer_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func TestCanBreed(t *testing.T) {
+	entry := func(gender poketrainer.Gender, eggGroups ...string) poketrainer.DaycarePokemon {
+		return poketrainer.DaycarePokemon{
+			Individual: poketrainer.Individual{Gender: gender},
+			EggGroups:  eggGroups,
+		}
+	}
+
+	cases := []struct {
+		name       string
+		first      poketrainer.DaycarePokemon
+		second     poketrainer.DaycarePokemon
+		compatible bool
+	}{
+		{
+			name:       "Opposite genders sharing an egg group",
+			first:      entry(poketrainer.GenderMale, "ground", "fairy"),
+			second:     entry(poketrainer.GenderFemale, "fairy"),
+			compatible: true,
+		},
+		{
+			name:       "Same gender",
+			first:      entry(poketrainer.GenderFemale, "ground"),
+			second:     entry(poketrainer.GenderFemale, "ground"),
+			compatible: false,
+		},
+		{
+			name:       "No shared egg group",
+			first:      entry(poketrainer.GenderMale, "ground"),
+			second:     entry(poketrainer.GenderFemale, "water1"),
+			compatible: false,
+		},
+		{
+			name:       "Genderless with Ditto",
+			first:      entry(poketrainer.GenderGenderless, "mineral"),
+			second:     entry(poketrainer.GenderGenderless, "ditto"),
+			compatible: true,
+		},
+		{
+			name:       "Two Ditto",
+			first:      entry(poketrainer.GenderGenderless, "ditto"),
+			second:     entry(poketrainer.GenderGenderless, "ditto"),
+			compatible: false,
+		},
+		{
+			name:       "No eggs group with Ditto",
+			first:      entry(poketrainer.GenderGenderless, "no-eggs"),
+			second:     entry(poketrainer.GenderGenderless, "ditto"),
+			compatible: false,
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			err := poketrainer.CanBreed(testcase.first, testcase.second)
+
+			if testcase.compatible && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			if !testcase.compatible && !errors.Is(err, poketrainer.ErrNotCompatible) {
+				t.Errorf("Unexpected error: want %v, got %v", poketrainer.ErrNotCompatible, err)
+			}
+		})
+	}
+}
+
+func TestDaycareEggHatches(t *testing.T) {
+	var eventLog bytes.Buffer
+
+	trainer := poketrainer.NewTrainer()
+	trainer.SetEventLog(&eventLog)
+
+	parents := []struct {
+		name   string
+		gender poketrainer.Gender
+	}{
+		{name: "nidoking", gender: poketrainer.GenderMale},
+		{name: "nidoqueen", gender: poketrainer.GenderFemale},
+	}
+
+	for _, parent := range slices.All(parents) {
+		if err := trainer.AddPokemonToPokedex(parent.name, pokeapi.Pokemon{Name: parent.name}); err != nil {
+			t.Fatalf("Unable to add %s to the Pokedex: %v", parent.name, err)
+		}
+
+		entry := poketrainer.DaycarePokemon{
+			Name:       parent.name,
+			Individual: poketrainer.Individual{Gender: parent.gender},
+			EggGroups:  []string{"monster", "ground"},
+		}
+
+		if err := trainer.DepositInDaycare(entry); err != nil {
+			t.Fatalf("Unable to deposit %s: %v", parent.name, err)
+		}
+	}
+
+	eggDue := false
+
+	for range poketrainer.EggLayingSteps {
+		var err error
+
+		if _, eggDue, err = trainer.DaycareStep(); err != nil {
+			t.Fatalf("Unable to take a step: %v", err)
+		}
+	}
+
+	if !eggDue {
+		t.Fatalf("An egg was not due after %d steps", poketrainer.EggLayingSteps)
+	}
+
+	egg := poketrainer.Egg{
+		Species:        "nidoran-f",
+		Pokemon:        pokeapi.Pokemon{Name: "nidoran-f"},
+		Individual:     poketrainer.Individual{Gender: poketrainer.GenderFemale},
+		StepsRemaining: 2,
+	}
+
+	if err := trainer.LayEgg(egg); err != nil {
+		t.Fatalf("Unable to lay the egg: %v", err)
+	}
+
+	var hatched []string
+
+	for range egg.StepsRemaining {
+		var err error
+
+		if hatched, _, err = trainer.DaycareStep(); err != nil {
+			t.Fatalf("Unable to take a step: %v", err)
+		}
+	}
+
+	if !slices.Equal(hatched, []string{"nidoran-f"}) {
+		t.Fatalf("Unexpected Pokemon hatched: want [nidoran-f], got %v", hatched)
+	}
+
+	loaded, err := poketrainer.LoadTrainer(&eventLog)
+	if err != nil {
+		t.Fatalf("Unable to load the trainer from the event log: %v", err)
+	}
+
+	individual, ok := loaded.Individual("nidoran-f")
+	if !ok {
+		t.Fatal("The hatched Pokemon was not found in the rebuilt Pokedex")
+	}
+
+	if individual.Gender != poketrainer.GenderFemale {
+		t.Errorf("Unexpected gender of the hatched Pokemon: want %s, got %s", poketrainer.GenderFemale, individual.Gender)
+	}
+
+	if got := len(loaded.Daycare()); got != 2 {
+		t.Errorf("Unexpected number of Pokemon at the daycare: want 2, got %d", got)
+	}
+}
@@ -0,0 +1,113 @@
+package poketrainer
+
+import (
+	"fmt"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+)
+
+type EventKind string
+
+const (
+	EventCatch    EventKind = "catch"
+	EventEscape   EventKind = "escape"
+	EventRelease  EventKind = "release"
+	EventVisit    EventKind = "visit"
+	EventMapPage  EventKind = "map-page"
+	EventUndo     EventKind = "undo"
+	EventRedo     EventKind = "redo"
+	EventTradeIn  EventKind = "trade-in"
+	EventTradeOut EventKind = "trade-out"
+
+	EventDaycareDeposit  EventKind = "daycare-deposit"
+	EventDaycareWithdraw EventKind = "daycare-withdraw"
+	EventDaycareStep     EventKind = "daycare-step"
+	EventEggLaid         EventKind = "egg-laid"
+)
+
+// Event is a change made to the trainer's state. Each event holds enough
+// information to both apply and revert the change.
+type Event struct {
+	Kind EventKind `json:"kind"`
+	Time time.Time `json:"time"`
+
+	// PokemonName is the name of the Pokemon that was caught, released,
+	// traded or that escaped.
+	PokemonName string `json:"pokemonName,omitempty"`
+
+	// Pokemon is the details of the Pokemon that was caught, released or traded.
+	Pokemon *pokeapi.Pokemon `json:"pokemon,omitempty"`
+
+	// Individual is the individual traits of the Pokemon that was released or traded.
+	Individual *Individual `json:"individual,omitempty"`
+
+	// HeldItem is the item held by the Pokemon that was caught, released or traded.
+	HeldItem string `json:"heldItem,omitempty"`
+
+	// LocationArea is the location area the trainer was in when the event occurred.
+	LocationArea string `json:"locationArea,omitempty"`
+
+	// PreviousLocationArea is the location area the trainer was in before visiting
+	// a new location area.
+	PreviousLocationArea string `json:"previousLocationArea,omitempty"`
+
+	// AreaPokemon is the list of Pokemon that can be encountered in the visited
+	// location area.
+	AreaPokemon []string `json:"areaPokemon,omitempty"`
+
+	// Previous and Next are the URLs of the previous and next pages of location areas
+	// after paging through the map. OldPrevious and OldNext are the values they replaced.
+	Previous    *string `json:"previous,omitempty"`
+	Next        *string `json:"next,omitempty"`
+	OldPrevious *string `json:"oldPrevious,omitempty"`
+	OldNext     *string `json:"oldNext,omitempty"`
+
+	// DaycarePokemon is the Pokemon that was left at the daycare.
+	DaycarePokemon *DaycarePokemon `json:"daycarePokemon,omitempty"`
+
+	// Egg is the egg that was produced at the daycare.
+	Egg *Egg `json:"egg,omitempty"`
+}
+
+func (e Event) String() string {
+	switch e.Kind {
+	case EventCatch:
+		return "catch " + e.PokemonName
+	case EventRelease:
+		return "release " + e.PokemonName
+	case EventEscape:
+		return e.PokemonName + " escaping"
+	case EventVisit:
+		return "visit " + e.LocationArea
+	case EventMapPage:
+		return "map paging"
+	case EventTradeIn:
+		return "trade in " + e.PokemonName
+	case EventTradeOut:
+		return "trade out " + e.PokemonName
+	case EventDaycareDeposit:
+		return "deposit " + e.PokemonName + " at the daycare"
+	case EventDaycareWithdraw:
+		return "withdraw " + e.PokemonName + " from the daycare"
+	case EventDaycareStep:
+		return "daycare step"
+	case EventEggLaid:
+		return "egg laid"
+	case EventUndo, EventRedo:
+		return string(e.Kind)
+	default:
+		return fmt.Sprintf("unknown event %q", e.Kind)
+	}
+}
+
+// undoable returns true if the event changes the trainer's state
+// and can therefore be undone.
+func (e Event) undoable() bool {
+	switch e.Kind {
+	case EventCatch, EventRelease, EventVisit, EventMapPage:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,173 @@
+package poketrainer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	ErrNothingToUndo = errors.New("there is nothing to undo")
+	ErrNothingToRedo = errors.New("there is nothing to redo")
+)
+
+// LoadTrainer creates a new trainer and rebuilds its state by replaying
+// the events from the event log.
+func LoadTrainer(eventLog io.Reader) (*Trainer, error) {
+	trainer := NewTrainer()
+
+	scanner := bufio.NewScanner(eventLog)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		data := scanner.Bytes()
+		if len(data) == 0 {
+			continue
+		}
+
+		var event Event
+
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("unable to decode the event on line %d: %w", line, err)
+		}
+
+		if err := trainer.process(event); err != nil {
+			return nil, fmt.Errorf("unable to replay the event on line %d: %w", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read the event log: %w", err)
+	}
+
+	// Achievements unlocked and eggs hatched during the replay are not new to the trainer.
+	trainer.newAchievements = nil
+	trainer.daycare.hatched = nil
+
+	return trainer, nil
+}
+
+// SetEventLog sets the writer that all future events are appended to.
+func (t *Trainer) SetEventLog(eventLog io.Writer) {
+	t.eventLog = eventLog
+}
+
+// Undo reverts the most recent change to the trainer's state and returns
+// the event that was undone.
+func (t *Trainer) Undo() (Event, error) {
+	if len(t.undoStack) == 0 {
+		return Event{}, ErrNothingToUndo
+	}
+
+	event := t.undoStack[len(t.undoStack)-1]
+
+	if err := t.record(Event{Kind: EventUndo, Time: time.Now()}); err != nil {
+		return Event{}, err
+	}
+
+	return event, nil
+}
+
+// Redo re-applies the most recently undone change to the trainer's state
+// and returns the event that was redone.
+func (t *Trainer) Redo() (Event, error) {
+	if len(t.redoStack) == 0 {
+		return Event{}, ErrNothingToRedo
+	}
+
+	event := t.redoStack[len(t.redoStack)-1]
+
+	if err := t.record(Event{Kind: EventRedo, Time: time.Now()}); err != nil {
+		return Event{}, err
+	}
+
+	return event, nil
+}
+
+// record processes the event and, once it has been applied successfully,
+// appends it to the event log so that rejected events are never replayed.
+func (t *Trainer) record(event Event) error {
+	if err := t.process(event); err != nil {
+		return err
+	}
+
+	if t.eventLog == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("unable to encode the event: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	if _, err := t.eventLog.Write(data); err != nil {
+		return fmt.Errorf("unable to write the event to the event log: %w", err)
+	}
+
+	return nil
+}
+
+// process applies the event to the trainer's state and updates the undo and redo history.
+func (t *Trainer) process(event Event) error {
+	switch event.Kind {
+	case EventUndo:
+		if len(t.undoStack) == 0 {
+			return ErrNothingToUndo
+		}
+
+		undone := t.undoStack[len(t.undoStack)-1]
+		t.undoStack = t.undoStack[:len(t.undoStack)-1]
+
+		t.revertEvent(undone)
+		t.redoStack = append(t.redoStack, undone)
+	case EventRedo:
+		if len(t.redoStack) == 0 {
+			return ErrNothingToRedo
+		}
+
+		redone := t.redoStack[len(t.redoStack)-1]
+		t.redoStack = t.redoStack[:len(t.redoStack)-1]
+
+		t.applyEvent(redone)
+		t.undoStack = append(t.undoStack, redone)
+	case EventCatch, EventRelease, EventVisit, EventMapPage, EventEscape:
+		t.applyEvent(event)
+		t.updateStatistics(event)
+
+		if event.undoable() {
+			t.undoStack = append(t.undoStack, event)
+			t.redoStack = nil
+		}
+	case EventTradeIn, EventTradeOut, EventDaycareDeposit, EventDaycareWithdraw:
+		// A traded Pokemon now belongs to another trainer and a Pokemon at the daycare
+		// is out of the trainer's hands, so these changes cannot be undone. The history
+		// is cleared so that earlier changes cannot restore or remove these Pokemon.
+		t.applyEvent(event)
+		t.updateStatistics(event)
+
+		t.undoStack = nil
+		t.redoStack = nil
+	case EventEggLaid:
+		t.applyEvent(event)
+	case EventDaycareStep:
+		t.applyEvent(event)
+
+		if len(t.daycare.hatched) > 0 {
+			t.undoStack = nil
+			t.redoStack = nil
+		}
+	default:
+		return fmt.Errorf("unknown event kind %q", event.Kind)
+	}
+
+	return nil
+}
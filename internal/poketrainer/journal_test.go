@@ -0,0 +1,121 @@
+package poketrainer_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func TestUndoRedo(t *testing.T) {
+	trainer := poketrainer.NewTrainer()
+
+	pikachu := pokeapi.Pokemon{ID: 25, Name: "pikachu"}
+
+	if err := trainer.AddPokemonHoldingItem("pikachu", pikachu, "light-ball"); err != nil {
+		t.Fatalf("Unable to add pikachu to the Pokedex: %v", err)
+	}
+
+	if err := trainer.RemovePokemonFromPokedex("pikachu"); err != nil {
+		t.Fatalf("Unable to release pikachu: %v", err)
+	}
+
+	event, err := trainer.Undo()
+	if err != nil {
+		t.Fatalf("Unable to undo the release: %v", err)
+	}
+
+	if event.Kind != poketrainer.EventRelease {
+		t.Errorf("Unexpected event undone: want %s, got %s", poketrainer.EventRelease, event.Kind)
+	}
+
+	if _, ok := trainer.GetPokemonFromPokedex("pikachu"); !ok {
+		t.Fatal("pikachu was not restored to the Pokedex after undoing the release")
+	}
+
+	if item, _ := trainer.HeldItem("pikachu"); item != "light-ball" {
+		t.Errorf("Unexpected held item after undoing the release: want light-ball, got %q", item)
+	}
+
+	if _, err := trainer.Redo(); err != nil {
+		t.Fatalf("Unable to redo the release: %v", err)
+	}
+
+	if _, ok := trainer.GetPokemonFromPokedex("pikachu"); ok {
+		t.Error("pikachu was found in the Pokedex after redoing the release")
+	}
+
+	if _, ok := trainer.HeldItem("pikachu"); ok {
+		t.Error("pikachu's held item was still found after redoing the release")
+	}
+
+	if _, err := trainer.Redo(); !errors.Is(err, poketrainer.ErrNothingToRedo) {
+		t.Errorf("Unexpected error after redoing with an empty history: want %v, got %v", poketrainer.ErrNothingToRedo, err)
+	}
+}
+
+func TestLoadTrainer(t *testing.T) {
+	var eventLog bytes.Buffer
+
+	trainer := poketrainer.NewTrainer()
+	trainer.SetEventLog(&eventLog)
+
+	area := pokeapi.LocationArea{
+		Name: "iron-island-area",
+		PokemonEncounters: []pokeapi.PokemonEncounter{
+			{Pokemon: pokeapi.NamedAPIResource{Name: "wingull"}},
+		},
+	}
+
+	steps := []func() error{
+		func() error { return trainer.UpdateCurrentLocationArea(area) },
+		func() error { return trainer.RecordEscape("wingull") },
+		func() error {
+			return trainer.AddPokemonHoldingItem("wingull", pokeapi.Pokemon{Name: "wingull"}, "pretty-feather")
+		},
+		func() error { return trainer.AddPokemonToPokedex("gyarados", pokeapi.Pokemon{Name: "gyarados"}) },
+		func() error { _, err := trainer.Undo(); return err },
+	}
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("Unable to update the trainer: %v", err)
+		}
+	}
+
+	loaded, err := poketrainer.LoadTrainer(&eventLog)
+	if err != nil {
+		t.Fatalf("Unable to load the trainer from the event log: %v", err)
+	}
+
+	if got := loaded.CurrentLocationAreaName(); got != area.Name {
+		t.Errorf("Unexpected location area: want %s, got %s", area.Name, got)
+	}
+
+	if _, ok := loaded.GetPokemonFromPokedex("wingull"); !ok {
+		t.Error("wingull was not found in the rebuilt Pokedex")
+	}
+
+	if item, _ := loaded.HeldItem("wingull"); item != "pretty-feather" {
+		t.Errorf("Unexpected held item in the rebuilt Pokedex: want pretty-feather, got %q", item)
+	}
+
+	if _, ok := loaded.GetPokemonFromPokedex("gyarados"); ok {
+		t.Error("gyarados was found in the rebuilt Pokedex after its catch was undone")
+	}
+
+	want := trainer.Statistics()
+	if got := loaded.Statistics(); got != want {
+		t.Errorf("Unexpected statistics: want %+v, got %+v", want, got)
+	}
+
+	if _, err := loaded.Redo(); err != nil {
+		t.Fatalf("Unable to redo the undone catch after loading: %v", err)
+	}
+
+	if _, ok := loaded.GetPokemonFromPokedex("gyarados"); !ok {
+		t.Error("gyarados was not found in the Pokedex after redoing its catch")
+	}
+}
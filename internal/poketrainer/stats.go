@@ -0,0 +1,134 @@
+package poketrainer
+
+import (
+	"maps"
+	"slices"
+)
+
+// pokemonTypes is the list of all the Pokemon types that can be collected.
+var pokemonTypes = []string{
+	"normal",
+	"fire",
+	"water",
+	"grass",
+	"electric",
+	"ice",
+	"fighting",
+	"poison",
+	"ground",
+	"flying",
+	"psychic",
+	"bug",
+	"rock",
+	"ghost",
+	"dragon",
+	"dark",
+	"steel",
+	"fairy",
+}
+
+// Statistics is a record of the trainer's activity in the Pokemon world.
+type Statistics struct {
+	ThrowsAttempted int
+	Catches         int
+	Escapes         int
+	Releases        int
+	AreasVisited    int
+	SpeciesCaught   int
+	TypesCollected  int
+	TotalTypes      int
+}
+
+type statistics struct {
+	throwsAttempted int
+	catches         int
+	escapes         int
+	releases        int
+	areasVisited    map[string]struct{}
+	speciesCaught   map[string]struct{}
+	typesCollected  map[string]struct{}
+
+	// areaPokemon maps each visited location area to the names of the
+	// Pokemon that can be encountered there.
+	areaPokemon map[string][]string
+
+	// areaCatches maps each location area to the names of the Pokemon
+	// that were caught there.
+	areaCatches map[string]map[string]struct{}
+}
+
+func newStatistics() statistics {
+	return statistics{
+		throwsAttempted: 0,
+		catches:         0,
+		escapes:         0,
+		releases:        0,
+		areasVisited:    make(map[string]struct{}),
+		speciesCaught:   make(map[string]struct{}),
+		typesCollected:  make(map[string]struct{}),
+		areaPokemon:     make(map[string][]string),
+		areaCatches:     make(map[string]map[string]struct{}),
+	}
+}
+
+// completedAreas returns the names of the location areas where the trainer
+// has caught every Pokemon that can be encountered there.
+func (s statistics) completedAreas() []string {
+	completed := make([]string, 0)
+
+	for area, pokemon := range maps.All(s.areaPokemon) {
+		if len(pokemon) == 0 {
+			continue
+		}
+
+		caught := s.areaCatches[area]
+		complete := true
+
+		for _, name := range slices.All(pokemon) {
+			if _, ok := caught[name]; !ok {
+				complete = false
+
+				break
+			}
+		}
+
+		if complete {
+			completed = append(completed, area)
+		}
+	}
+
+	slices.Sort(completed)
+
+	return completed
+}
+
+// Statistics returns a summary of the trainer's statistics.
+func (t *Trainer) Statistics() Statistics {
+	return Statistics{
+		ThrowsAttempted: t.stats.throwsAttempted,
+		Catches:         t.stats.catches,
+		Escapes:         t.stats.escapes,
+		Releases:        t.stats.releases,
+		AreasVisited:    len(t.stats.areasVisited),
+		SpeciesCaught:   len(t.stats.speciesCaught),
+		TypesCollected:  len(t.stats.typesCollected),
+		TotalTypes:      len(pokemonTypes),
+	}
+}
+
+// CollectedTypes returns the sorted list of Pokemon types that the
+// trainer has collected.
+func (t *Trainer) CollectedTypes() []string {
+	return slices.Sorted(maps.Keys(t.stats.typesCollected))
+}
+
+// VisitedLocationAreas returns the sorted list of location areas that the trainer has visited.
+func (t *Trainer) VisitedLocationAreas() []string {
+	return slices.Sorted(maps.Keys(t.stats.areasVisited))
+}
+
+// CompletedLocationAreas returns the sorted list of location areas where the
+// trainer has caught every Pokemon that can be encountered there.
+func (t *Trainer) CompletedLocationAreas() []string {
+	return t.stats.completedAreas()
+}
@@ -0,0 +1,185 @@
+package poketrainer_test
+
+import (
+	"slices"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+)
+
+func TestStatistics(t *testing.T) {
+	area := pokeapi.LocationArea{
+		Name: "mt-coronet-1f-route-207",
+		PokemonEncounters: []pokeapi.PokemonEncounter{
+			{Pokemon: pokeapi.NamedAPIResource{Name: "geodude"}},
+			{Pokemon: pokeapi.NamedAPIResource{Name: "zubat"}},
+		},
+	}
+
+	geodude := pokemonOfTypes("geodude", "rock", "ground")
+	zubat := pokemonOfTypes("zubat", "poison", "flying")
+
+	cases := []struct {
+		name  string
+		steps []func(*poketrainer.Trainer) error
+		want  poketrainer.Statistics
+	}{
+		{
+			name:  "New trainer",
+			steps: nil,
+			want: poketrainer.Statistics{
+				ThrowsAttempted: 0,
+				Catches:         0,
+				Escapes:         0,
+				Releases:        0,
+				AreasVisited:    0,
+				SpeciesCaught:   0,
+				TypesCollected:  0,
+				TotalTypes:      18,
+			},
+		},
+		{
+			name: "Catches and escapes are counted as throws",
+			steps: []func(*poketrainer.Trainer) error{
+				visit(area),
+				escape("zubat"),
+				catch("geodude", geodude),
+				catch("zubat", zubat),
+			},
+			want: poketrainer.Statistics{
+				ThrowsAttempted: 3,
+				Catches:         2,
+				Escapes:         1,
+				Releases:        0,
+				AreasVisited:    1,
+				SpeciesCaught:   2,
+				TypesCollected:  4,
+				TotalTypes:      18,
+			},
+		},
+		{
+			name: "Revisiting an area and recatching a species are not counted twice",
+			steps: []func(*poketrainer.Trainer) error{
+				visit(area),
+				catch("geodude", geodude),
+				release("geodude"),
+				visit(area),
+				catch("geodude", geodude),
+			},
+			want: poketrainer.Statistics{
+				ThrowsAttempted: 2,
+				Catches:         2,
+				Escapes:         0,
+				Releases:        1,
+				AreasVisited:    1,
+				SpeciesCaught:   1,
+				TypesCollected:  2,
+				TotalTypes:      18,
+			},
+		},
+		{
+			name: "Trades are not counted as catches or releases",
+			steps: []func(*poketrainer.Trainer) error{
+				catch("geodude", geodude),
+				func(trainer *poketrainer.Trainer) error { return trainer.TradeAwayPokemon("geodude") },
+				func(trainer *poketrainer.Trainer) error { return trainer.ReceiveTradedPokemon("zubat", zubat, nil, "") },
+			},
+			want: poketrainer.Statistics{
+				ThrowsAttempted: 1,
+				Catches:         1,
+				Escapes:         0,
+				Releases:        0,
+				AreasVisited:    0,
+				SpeciesCaught:   1,
+				TypesCollected:  2,
+				TotalTypes:      18,
+			},
+		},
+		{
+			name: "Undo and redo do not change the statistics",
+			steps: []func(*poketrainer.Trainer) error{
+				catch("geodude", geodude),
+				release("geodude"),
+				undo,
+				undo,
+				redo,
+			},
+			want: poketrainer.Statistics{
+				ThrowsAttempted: 1,
+				Catches:         1,
+				Escapes:         0,
+				Releases:        1,
+				AreasVisited:    0,
+				SpeciesCaught:   1,
+				TypesCollected:  2,
+				TotalTypes:      18,
+			},
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			trainer := poketrainer.NewTrainer()
+
+			for _, step := range slices.All(testcase.steps) {
+				if err := step(trainer); err != nil {
+					t.Fatalf("Unable to update the trainer: %v", err)
+				}
+			}
+
+			if got := trainer.Statistics(); got != testcase.want {
+				t.Errorf("Unexpected statistics: want %+v, got %+v", testcase.want, got)
+			}
+		})
+	}
+}
+
+func pokemonOfTypes(name string, types ...string) pokeapi.Pokemon {
+	pokemon := pokeapi.Pokemon{Name: name}
+
+	for ind, pType := range slices.All(types) {
+		pokemon.Types = append(pokemon.Types, pokeapi.PokemonType{
+			Slot: ind + 1,
+			Type: pokeapi.NamedAPIResource{Name: pType},
+		})
+	}
+
+	return pokemon
+}
+
+func visit(area pokeapi.LocationArea) func(*poketrainer.Trainer) error {
+	return func(trainer *poketrainer.Trainer) error {
+		return trainer.UpdateCurrentLocationArea(area)
+	}
+}
+
+func catch(name string, pokemon pokeapi.Pokemon) func(*poketrainer.Trainer) error {
+	return func(trainer *poketrainer.Trainer) error {
+		return trainer.AddPokemonToPokedex(name, pokemon)
+	}
+}
+
+func escape(name string) func(*poketrainer.Trainer) error {
+	return func(trainer *poketrainer.Trainer) error {
+		return trainer.RecordEscape(name)
+	}
+}
+
+func release(name string) func(*poketrainer.Trainer) error {
+	return func(trainer *poketrainer.Trainer) error {
+		return trainer.RemovePokemonFromPokedex(name)
+	}
+}
+
+func undo(trainer *poketrainer.Trainer) error {
+	_, err := trainer.Undo()
+
+	return err
+}
+
+func redo(trainer *poketrainer.Trainer) error {
+	_, err := trainer.Redo()
+
+	return err
+}
@@ -0,0 +1,55 @@
+package poketrainer
+
+import (
+	"fmt"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
+)
+
+// TradeAwayPokemon removes the Pokemon from the Pokedex after it has been
+// traded to another trainer.
+func (t *Trainer) TradeAwayPokemon(name string) error {
+	details, ok := t.pokedex[name]
+	if !ok {
+		return fmt.Errorf("%s is not in the Pokedex", name)
+	}
+
+	event := Event{
+		Kind:         EventTradeOut,
+		Time:         time.Now(),
+		PokemonName:  name,
+		Pokemon:      &details,
+		Individual:   t.individualPtr(name),
+		HeldItem:     t.heldItems[name],
+		LocationArea: t.currentLocationAreaName,
+	}
+
+	return t.record(event)
+}
+
+// ReceiveTradedPokemon adds a Pokemon traded from another trainer to the Pokedex
+// along with its individual traits, if they are known, and the item that it holds.
+// The trade is rejected if the Pokedex already holds a Pokemon with the same name.
+func (t *Trainer) ReceiveTradedPokemon(
+	name string,
+	details pokeapi.Pokemon,
+	individual *Individual,
+	heldItem string,
+) error {
+	if _, ok := t.pokedex[name]; ok {
+		return fmt.Errorf("you already have a %s in your Pokedex", name)
+	}
+
+	event := Event{
+		Kind:         EventTradeIn,
+		Time:         time.Now(),
+		PokemonName:  name,
+		Pokemon:      &details,
+		Individual:   individual,
+		HeldItem:     heldItem,
+		LocationArea: t.currentLocationAreaName,
+	}
+
+	return t.record(event)
+}
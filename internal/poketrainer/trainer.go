@@ -2,7 +2,10 @@ package poketrainer
 
 import (
 	"fmt"
+	"io"
 	"maps"
+	"slices"
+	"time"
 
 	"codeflow.dananglin.me.uk/apollo/pokecli/internal/api/pokeapi"
 )
@@ -12,6 +15,15 @@ type Trainer struct {
 	nextLocationArea        *string
 	currentLocationAreaName string
 	pokedex                 map[string]pokeapi.Pokemon
+	individuals             map[string]Individual
+	heldItems               map[string]string
+	daycare                 daycare
+	stats                   statistics
+	achievements            map[string]struct{}
+	newAchievements         []Achievement
+	undoStack               []Event
+	redoStack               []Event
+	eventLog                io.Writer
 }
 
 func NewTrainer() *Trainer {
@@ -20,14 +32,31 @@ func NewTrainer() *Trainer {
 		nextLocationArea:        nil,
 		currentLocationAreaName: "",
 		pokedex:                 make(map[string]pokeapi.Pokemon),
+		individuals:             make(map[string]Individual),
+		heldItems:               make(map[string]string),
+		daycare:                 daycare{pokemon: nil, eggs: nil, stepsSinceEgg: 0, nextEggID: 1, hatched: nil},
+		stats:                   newStatistics(),
+		achievements:            make(map[string]struct{}),
+		newAchievements:         nil,
+		undoStack:               nil,
+		redoStack:               nil,
+		eventLog:                nil,
 	}
 
 	return &trainer
 }
 
-func (t *Trainer) UpdateLocationAreas(previous, next *string) {
-	t.previousLocationArea = previous
-	t.nextLocationArea = next
+func (t *Trainer) UpdateLocationAreas(previous, next *string) error {
+	event := Event{
+		Kind:        EventMapPage,
+		Time:        time.Now(),
+		Previous:    previous,
+		Next:        next,
+		OldPrevious: t.previousLocationArea,
+		OldNext:     t.nextLocationArea,
+	}
+
+	return t.record(event)
 }
 
 func (t *Trainer) PreviousLocationArea() *string {
@@ -38,8 +67,41 @@ func (t *Trainer) NextLocationArea() *string {
 	return t.nextLocationArea
 }
 
-func (t *Trainer) AddPokemonToPokedex(name string, details pokeapi.Pokemon) {
-	t.pokedex[name] = details
+func (t *Trainer) AddPokemonToPokedex(name string, details pokeapi.Pokemon) error {
+	return t.AddPokemonHoldingItem(name, details, "")
+}
+
+// AddPokemonHoldingItem adds a caught Pokemon that was holding the item to the Pokedex.
+func (t *Trainer) AddPokemonHoldingItem(name string, details pokeapi.Pokemon, heldItem string) error {
+	event := Event{
+		Kind:         EventCatch,
+		Time:         time.Now(),
+		PokemonName:  name,
+		Pokemon:      &details,
+		HeldItem:     heldItem,
+		LocationArea: t.currentLocationAreaName,
+	}
+
+	return t.record(event)
+}
+
+// HeldItem returns the item held by the Pokemon in the Pokedex, if any.
+func (t *Trainer) HeldItem(name string) (string, bool) {
+	item, ok := t.heldItems[name]
+
+	return item, ok
+}
+
+// RecordEscape records a Pokemon escaping from the trainer's Pokeball.
+func (t *Trainer) RecordEscape(name string) error {
+	event := Event{
+		Kind:         EventEscape,
+		Time:         time.Now(),
+		PokemonName:  name,
+		LocationArea: t.currentLocationAreaName,
+	}
+
+	return t.record(event)
 }
 
 func (t *Trainer) GetPokemonFromPokedex(name string) (pokeapi.Pokemon, bool) {
@@ -48,28 +110,162 @@ func (t *Trainer) GetPokemonFromPokedex(name string) (pokeapi.Pokemon, bool) {
 	return details, ok
 }
 
-func (t *Trainer) RemovePokemonFromPokedex(name string) {
-	delete(t.pokedex, name)
+func (t *Trainer) RemovePokemonFromPokedex(name string) error {
+	details, ok := t.pokedex[name]
+	if !ok {
+		return fmt.Errorf("%s is not in the Pokedex", name)
+	}
+
+	event := Event{
+		Kind:         EventRelease,
+		Time:         time.Now(),
+		PokemonName:  name,
+		Pokemon:      &details,
+		Individual:   t.individualPtr(name),
+		HeldItem:     t.heldItems[name],
+		LocationArea: t.currentLocationAreaName,
+	}
+
+	return t.record(event)
 }
 
-func (t *Trainer) ListAllPokemonFromPokedex() {
-	if len(t.pokedex) == 0 {
-		fmt.Println("You have no Pokemon in your Pokedex.")
+// PokedexNames returns the sorted names of all the Pokemon in the Pokedex.
+func (t *Trainer) PokedexNames() []string {
+	return slices.Sorted(maps.Keys(t.pokedex))
+}
 
-		return
+func (t *Trainer) CurrentLocationAreaName() string {
+	return t.currentLocationAreaName
+}
+
+// UpdateCurrentLocationArea sets the trainer's current location area and
+// records the Pokemon that can be encountered there.
+func (t *Trainer) UpdateCurrentLocationArea(locationArea pokeapi.LocationArea) error {
+	pokemon := make([]string, len(locationArea.PokemonEncounters))
+
+	for ind, encounter := range slices.All(locationArea.PokemonEncounters) {
+		pokemon[ind] = encounter.Pokemon.Name
+	}
+
+	event := Event{
+		Kind:                 EventVisit,
+		Time:                 time.Now(),
+		LocationArea:         locationArea.Name,
+		PreviousLocationArea: t.currentLocationAreaName,
+		AreaPokemon:          pokemon,
 	}
 
-	fmt.Println("Your Pokedex:")
+	return t.record(event)
+}
+
+// applyEvent applies the change described by the event to the trainer's state.
+func (t *Trainer) applyEvent(event Event) {
+	switch event.Kind {
+	case EventCatch, EventTradeIn:
+		if event.Pokemon != nil {
+			t.pokedex[event.PokemonName] = *event.Pokemon
+		}
 
-	for name := range maps.All(t.pokedex) {
-		fmt.Println("  -", name)
+		if event.Individual != nil {
+			t.individuals[event.PokemonName] = *event.Individual
+		} else {
+			delete(t.individuals, event.PokemonName)
+		}
+
+		if event.HeldItem != "" {
+			t.heldItems[event.PokemonName] = event.HeldItem
+		} else {
+			delete(t.heldItems, event.PokemonName)
+		}
+	case EventRelease, EventTradeOut:
+		delete(t.pokedex, event.PokemonName)
+		delete(t.individuals, event.PokemonName)
+		delete(t.heldItems, event.PokemonName)
+	case EventVisit:
+		t.currentLocationAreaName = event.LocationArea
+	case EventMapPage:
+		t.previousLocationArea = event.Previous
+		t.nextLocationArea = event.Next
+	case EventDaycareDeposit, EventDaycareWithdraw, EventDaycareStep, EventEggLaid:
+		t.applyDaycareEvent(event)
+	case EventEscape, EventUndo, EventRedo:
 	}
 }
 
-func (t *Trainer) CurrentLocationAreaName() string {
-	return t.currentLocationAreaName
+// revertEvent reverts the change described by the event from the trainer's state.
+func (t *Trainer) revertEvent(event Event) {
+	switch event.Kind {
+	case EventCatch:
+		delete(t.pokedex, event.PokemonName)
+		delete(t.individuals, event.PokemonName)
+		delete(t.heldItems, event.PokemonName)
+	case EventRelease:
+		if event.Pokemon != nil {
+			t.pokedex[event.PokemonName] = *event.Pokemon
+		}
+
+		if event.Individual != nil {
+			t.individuals[event.PokemonName] = *event.Individual
+		}
+
+		if event.HeldItem != "" {
+			t.heldItems[event.PokemonName] = event.HeldItem
+		}
+	case EventVisit:
+		t.currentLocationAreaName = event.PreviousLocationArea
+	case EventMapPage:
+		t.previousLocationArea = event.OldPrevious
+		t.nextLocationArea = event.OldNext
+	case EventEscape, EventUndo, EventRedo, EventTradeIn, EventTradeOut,
+		EventDaycareDeposit, EventDaycareWithdraw, EventDaycareStep, EventEggLaid:
+	}
+}
+
+// updateStatistics updates the trainer's statistics from the event and
+// checks for any newly unlocked achievements. Statistics are a lifetime
+// record of the trainer's activity so they are not affected by undo or redo.
+func (t *Trainer) updateStatistics(event Event) {
+	switch event.Kind {
+	case EventCatch:
+		t.stats.throwsAttempted++
+		t.stats.catches++
+		t.stats.speciesCaught[event.PokemonName] = struct{}{}
+
+		if event.Pokemon != nil {
+			for _, pType := range slices.All(event.Pokemon.Types) {
+				t.stats.typesCollected[pType.Type.Name] = struct{}{}
+			}
+		}
+
+		if event.LocationArea != "" {
+			if _, ok := t.stats.areaCatches[event.LocationArea]; !ok {
+				t.stats.areaCatches[event.LocationArea] = make(map[string]struct{})
+			}
+
+			t.stats.areaCatches[event.LocationArea][event.PokemonName] = struct{}{}
+		}
+	case EventEscape:
+		t.stats.throwsAttempted++
+		t.stats.escapes++
+	case EventRelease:
+		t.stats.releases++
+	case EventVisit:
+		t.stats.areasVisited[event.LocationArea] = struct{}{}
+		t.stats.areaPokemon[event.LocationArea] = event.AreaPokemon
+	case EventMapPage, EventUndo, EventRedo, EventTradeIn, EventTradeOut,
+		EventDaycareDeposit, EventDaycareWithdraw, EventDaycareStep, EventEggLaid:
+	}
+
+	t.checkAchievements()
 }
 
-func (t *Trainer) UpdateCurrentLocationAreaName(locationName string) {
-	t.currentLocationAreaName = locationName
+// individualPtr returns a pointer to a copy of the individual traits of the
+// Pokemon in the Pokedex, or nil if they are not known.
+func (t *Trainer) individualPtr(name string) *Individual {
+	individual, ok := t.individuals[name]
+	if !ok {
+		return nil
+	}
+
+	return &individual
 }
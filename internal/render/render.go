@@ -0,0 +1,160 @@
+// Package render styles the output of the commands with the colours of the
+// selected theme.
+//
+// The text is only coloured when it is written directly to a terminal that
+// supports colours, so the output that is piped into a filter, written to a
+// file or shown in the terminal UI stays plain. Colours are also disabled
+// when the NO_COLOR environment variable is set.
+package render
+
+import (
+	"image/color"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/sprite"
+)
+
+const (
+	// StatBarWidth is the width of a stat bar in characters.
+	StatBarWidth = 20
+
+	// maxBaseStat is the highest base stat of any Pokemon.
+	maxBaseStat = 255
+
+	lowStatThreshold    = 60
+	mediumStatThreshold = 100
+
+	barFilled = '█'
+	barEmpty  = '░'
+
+	resetColour = "\x1b[0m"
+)
+
+// Renderer holds the theme and the colours supported by the terminal.
+type Renderer struct {
+	mu    sync.RWMutex
+	theme Theme
+	mode  sprite.ColourMode
+}
+
+// NewRenderer returns a renderer that styles the output with the theme
+// for a terminal that supports the colour mode.
+func NewRenderer(theme Theme, mode sprite.ColourMode) *Renderer {
+	return &Renderer{
+		mu:    sync.RWMutex{},
+		theme: theme,
+		mode:  mode,
+	}
+}
+
+// Theme returns the current theme.
+func (r *Renderer) Theme() Theme {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.theme
+}
+
+// SetTheme changes the theme.
+func (r *Renderer) SetTheme(theme Theme) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.theme = theme
+}
+
+// Painter returns the painter that styles the text written to out.
+// The painter does not use colours if out is not a terminal.
+func (r *Renderer) Painter(out io.Writer) Painter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mode := r.mode
+	if !isTerminal(out) {
+		mode = sprite.ColourNone
+	}
+
+	return NewPainter(r.theme, mode)
+}
+
+// Painter styles text for a single writer.
+type Painter struct {
+	theme Theme
+	mode  sprite.ColourMode
+}
+
+// NewPainter returns a painter that styles text with the theme in the colour mode.
+func NewPainter(theme Theme, mode sprite.ColourMode) Painter {
+	return Painter{theme: theme, mode: mode}
+}
+
+// Mode returns the colours that the painter uses.
+func (p Painter) Mode() sprite.ColourMode {
+	return p.mode
+}
+
+// Type returns the name of the Pokemon type in the type's colour.
+func (p Painter) Type(name string) string {
+	return p.paint(name, p.theme.Types[name])
+}
+
+// Types returns the names of the Pokemon types in their colours, separated by commas.
+func (p Painter) Types(names []string) string {
+	painted := make([]string, len(names))
+
+	for ind := range names {
+		painted[ind] = p.Type(names[ind])
+	}
+
+	return strings.Join(painted, ", ")
+}
+
+// Error returns the text in the colour for errors.
+func (p Painter) Error(text string) string {
+	return p.paint(text, p.theme.Error)
+}
+
+// StatBar returns a horizontal bar that is filled in proportion to the
+// base stat. The bar is coloured by how high the base stat is.
+func (p Painter) StatBar(value int) string {
+	filled := min(StatBarWidth, max(0, (value*StatBarWidth+maxBaseStat-1)/maxBaseStat))
+
+	colour := p.theme.StatHigh
+
+	switch {
+	case value < lowStatThreshold:
+		colour = p.theme.StatLow
+	case value < mediumStatThreshold:
+		colour = p.theme.StatMedium
+	}
+
+	bar := p.paint(strings.Repeat(string(barFilled), filled), colour)
+
+	return bar + strings.Repeat(string(barEmpty), StatBarWidth-filled)
+}
+
+func (p Painter) paint(text string, colour color.Color) string {
+	if p.mode == sprite.ColourNone || colour == nil || text == "" {
+		return text
+	}
+
+	return sprite.Sequence(colour, nil, p.mode) + text + resetColour
+}
+
+// isTerminal returns true if out is a terminal (a character device).
+func isTerminal(out io.Writer) bool {
+	file, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,101 @@
+package render_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/render"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/sprite"
+)
+
+func TestPainter(t *testing.T) {
+	classic, _ := render.LookupTheme("classic")
+	mono, _ := render.LookupTheme("mono")
+
+	cases := []struct {
+		name    string
+		painter render.Painter
+		got     func(render.Painter) string
+		want    string
+	}{
+		{
+			name:    "Type colour",
+			painter: render.NewPainter(classic, sprite.ColourTrueColour),
+			got:     func(p render.Painter) string { return p.Type("fire") },
+			want:    "\x1b[39;49m\x1b[38;2;238;129;48mfire\x1b[0m",
+		},
+		{
+			name:    "Unknown type",
+			painter: render.NewPainter(classic, sprite.ColourTrueColour),
+			got:     func(p render.Painter) string { return p.Type("shadow") },
+			want:    "shadow",
+		},
+		{
+			name:    "No colours",
+			painter: render.NewPainter(classic, sprite.ColourNone),
+			got:     func(p render.Painter) string { return p.Types([]string{"water", "rock"}) },
+			want:    "water, rock",
+		},
+		{
+			name:    "Mono theme",
+			painter: render.NewPainter(mono, sprite.Colour256),
+			got:     func(p render.Painter) string { return p.Error("ERROR: oops.") },
+			want:    "ERROR: oops.",
+		},
+		{
+			name:    "Error colour",
+			painter: render.NewPainter(classic, sprite.Colour256),
+			got:     func(p render.Painter) string { return p.Error("ERROR: oops.") },
+			want:    "\x1b[39;49m\x1b[38;5;167mERROR: oops.\x1b[0m",
+		},
+		{
+			name:    "Stat bar",
+			painter: render.NewPainter(mono, sprite.ColourNone),
+			got:     func(p render.Painter) string { return p.StatBar(90) },
+			want:    "████████░░░░░░░░░░░░",
+		},
+		{
+			name:    "Maximum stat bar",
+			painter: render.NewPainter(mono, sprite.ColourNone),
+			got:     func(p render.Painter) string { return p.StatBar(300) },
+			want:    strings.Repeat("█", render.StatBarWidth),
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			if got := testcase.got(testcase.painter); got != testcase.want {
+				t.Errorf("Unexpected styled text: want %q, got %q", testcase.want, got)
+			}
+		})
+	}
+}
+
+func TestPainterIsPlainForNonTerminals(t *testing.T) {
+	classic, _ := render.LookupTheme(render.DefaultTheme)
+	renderer := render.NewRenderer(classic, sprite.ColourTrueColour)
+
+	painter := renderer.Painter(new(bytes.Buffer))
+
+	if painter.Mode() != sprite.ColourNone {
+		t.Errorf("Unexpected colour mode for a buffer: want %d, got %d", sprite.ColourNone, painter.Mode())
+	}
+
+	if got := painter.Type("grass"); got != "grass" {
+		t.Errorf("Unexpected type for a buffer: want %q, got %q", "grass", got)
+	}
+}
+
+func TestLookupTheme(t *testing.T) {
+	for _, name := range slices.All(render.ThemeNames()) {
+		if theme, ok := render.LookupTheme(name); !ok || theme.Name != name {
+			t.Errorf("Unable to look up the %s theme", name)
+		}
+	}
+
+	if _, ok := render.LookupTheme("neon"); ok {
+		t.Error("An unknown theme was found")
+	}
+}
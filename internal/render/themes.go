@@ -0,0 +1,124 @@
+package render
+
+import (
+	"image/color"
+	"maps"
+	"slices"
+)
+
+// DefaultTheme is the name of the theme that is used unless the user selects another one.
+const DefaultTheme = "classic"
+
+// Theme is the set of colours used to style the output. A nil colour leaves
+// the text in the terminal's default colour.
+type Theme struct {
+	Name        string
+	Description string
+	Types       map[string]color.Color
+	Error       color.Color
+	StatLow     color.Color
+	StatMedium  color.Color
+	StatHigh    color.Color
+}
+
+// typeColours are the canonical colours of the Pokemon types.
+var typeColours = map[string]color.Color{
+	"normal":   color.RGBA{R: 0xa8, G: 0xa7, B: 0x7a, A: 0xff},
+	"fire":     color.RGBA{R: 0xee, G: 0x81, B: 0x30, A: 0xff},
+	"water":    color.RGBA{R: 0x63, G: 0x90, B: 0xf0, A: 0xff},
+	"electric": color.RGBA{R: 0xf7, G: 0xd0, B: 0x2c, A: 0xff},
+	"grass":    color.RGBA{R: 0x7a, G: 0xc7, B: 0x4c, A: 0xff},
+	"ice":      color.RGBA{R: 0x96, G: 0xd9, B: 0xd6, A: 0xff},
+	"fighting": color.RGBA{R: 0xc2, G: 0x2e, B: 0x28, A: 0xff},
+	"poison":   color.RGBA{R: 0xa3, G: 0x3e, B: 0xa1, A: 0xff},
+	"ground":   color.RGBA{R: 0xe2, G: 0xbf, B: 0x65, A: 0xff},
+	"flying":   color.RGBA{R: 0xa9, G: 0x8f, B: 0xf3, A: 0xff},
+	"psychic":  color.RGBA{R: 0xf9, G: 0x55, B: 0x87, A: 0xff},
+	"bug":      color.RGBA{R: 0xa6, G: 0xb9, B: 0x1a, A: 0xff},
+	"rock":     color.RGBA{R: 0xb6, G: 0xa1, B: 0x36, A: 0xff},
+	"ghost":    color.RGBA{R: 0x73, G: 0x57, B: 0x97, A: 0xff},
+	"dragon":   color.RGBA{R: 0x6f, G: 0x35, B: 0xfc, A: 0xff},
+	"dark":     color.RGBA{R: 0x70, G: 0x57, B: 0x46, A: 0xff},
+	"steel":    color.RGBA{R: 0xb7, G: 0xb7, B: 0xce, A: 0xff},
+	"fairy":    color.RGBA{R: 0xd6, G: 0x85, B: 0xad, A: 0xff},
+}
+
+var themes = []Theme{
+	{
+		Name:        "classic",
+		Description: "The canonical colours of the Pokemon types",
+		Types:       typeColours,
+		Error:       color.RGBA{R: 0xe0, G: 0x30, B: 0x30, A: 0xff},
+		StatLow:     color.RGBA{R: 0xf3, G: 0x44, B: 0x44, A: 0xff},
+		StatMedium:  color.RGBA{R: 0xff, G: 0xdd, B: 0x57, A: 0xff},
+		StatHigh:    color.RGBA{R: 0x23, G: 0xcd, B: 0x5e, A: 0xff},
+	},
+	{
+		Name:        "pastel",
+		Description: "Softer shades of the colours of the Pokemon types",
+		Types:       lightenAll(typeColours),
+		Error:       color.RGBA{R: 0xe8, G: 0x7a, B: 0x7a, A: 0xff},
+		StatLow:     color.RGBA{R: 0xf4, G: 0x9a, B: 0x9a, A: 0xff},
+		StatMedium:  color.RGBA{R: 0xf6, G: 0xd8, B: 0x8c, A: 0xff},
+		StatHigh:    color.RGBA{R: 0x8f, G: 0xd9, B: 0xa8, A: 0xff},
+	},
+	{
+		Name:        "mono",
+		Description: "No colours",
+		Types:       map[string]color.Color{},
+		Error:       nil,
+		StatLow:     nil,
+		StatMedium:  nil,
+		StatHigh:    nil,
+	},
+}
+
+// Themes returns all the available themes.
+func Themes() []Theme {
+	return slices.Clone(themes)
+}
+
+// ThemeNames returns the names of all the available themes.
+func ThemeNames() []string {
+	names := make([]string, len(themes))
+
+	for ind, theme := range slices.All(themes) {
+		names[ind] = theme.Name
+	}
+
+	return names
+}
+
+// LookupTheme returns the theme with the given name.
+func LookupTheme(name string) (Theme, bool) {
+	ind := slices.IndexFunc(themes, func(theme Theme) bool {
+		return theme.Name == name
+	})
+	if ind < 0 {
+		return Theme{}, false
+	}
+
+	return themes[ind], true
+}
+
+// lightenAll mixes each colour with white.
+func lightenAll(colours map[string]color.Color) map[string]color.Color {
+	lightened := make(map[string]color.Color, len(colours))
+
+	for name, colour := range maps.All(colours) {
+		red, green, blue, _ := colour.RGBA()
+
+		lightened[name] = color.RGBA{
+			R: lighten(red),
+			G: lighten(green),
+			B: lighten(blue),
+			A: 0xff,
+		}
+	}
+
+	return lightened
+}
+
+func lighten(value uint32) uint8 {
+	return uint8((value>>8 + 0xff) / 2)
+}
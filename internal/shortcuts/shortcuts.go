@@ -0,0 +1,294 @@
+// Package shortcuts manages the user's command aliases and macros.
+//
+// An alias is a short name for a command with its arguments. A macro is a
+// sequence of command lines recorded at the REPL and replayed by typing the
+// macro's name. Both are saved to a JSON file so that they are available the
+// next time the application starts.
+package shortcuts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/atomicfile"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/cmdline"
+)
+
+// maxMacroDepth is the maximum number of macros that can be nested
+// inside each other before the expansion is stopped.
+const maxMacroDepth = 8
+
+var (
+	ErrNotFound     = errors.New("shortcut not found")
+	ErrNameTaken    = errors.New("the name is already taken")
+	ErrNotRecording = errors.New("no macro is being recorded")
+	ErrRecording    = errors.New("a macro is already being recorded")
+)
+
+// Shortcuts is the set of aliases and macros defined by the user.
+type Shortcuts struct {
+	path    string
+	aliases map[string][]string
+	macros  map[string][]string
+
+	recording string
+	recorded  []string
+}
+
+type shortcutsFile struct {
+	Aliases map[string][]string `json:"aliases"`
+	Macros  map[string][]string `json:"macros"`
+}
+
+// New returns an empty set of shortcuts that is not saved to a file.
+func New() *Shortcuts {
+	return &Shortcuts{
+		path:      "",
+		aliases:   make(map[string][]string),
+		macros:    make(map[string][]string),
+		recording: "",
+		recorded:  nil,
+	}
+}
+
+// Load loads the shortcuts from the file at the path. Every change to the
+// shortcuts is saved back to the file. An empty set of shortcuts is returned
+// if the file does not exist yet.
+func Load(path string) (*Shortcuts, error) {
+	shortcuts := New()
+	shortcuts.path = path
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return shortcuts, nil
+		}
+
+		return nil, fmt.Errorf("unable to read the shortcuts file: %w", err)
+	}
+
+	var file shortcutsFile
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("unable to decode the shortcuts from %s: %w", path, err)
+	}
+
+	if file.Aliases != nil {
+		shortcuts.aliases = file.Aliases
+	}
+
+	if file.Macros != nil {
+		shortcuts.macros = file.Macros
+	}
+
+	return shortcuts, nil
+}
+
+// Alias returns the command words that the alias expands to.
+func (s *Shortcuts) Alias(name string) ([]string, bool) {
+	words, ok := s.aliases[name]
+
+	return words, ok
+}
+
+// AliasNames returns the names of the aliases in alphabetical order.
+func (s *Shortcuts) AliasNames() []string {
+	return slices.Sorted(maps.Keys(s.aliases))
+}
+
+// SetAlias creates or replaces the alias.
+func (s *Shortcuts) SetAlias(name string, words []string) error {
+	if _, ok := s.macros[name]; ok {
+		return fmt.Errorf("%w by a macro", ErrNameTaken)
+	}
+
+	s.aliases[name] = slices.Clone(words)
+
+	return s.save()
+}
+
+// RemoveAlias removes the alias.
+func (s *Shortcuts) RemoveAlias(name string) error {
+	if _, ok := s.aliases[name]; !ok {
+		return fmt.Errorf("%w: there is no alias called %q", ErrNotFound, name)
+	}
+
+	delete(s.aliases, name)
+
+	return s.save()
+}
+
+// Macro returns the command lines recorded in the macro.
+func (s *Shortcuts) Macro(name string) ([]string, bool) {
+	lines, ok := s.macros[name]
+
+	return lines, ok
+}
+
+// MacroNames returns the names of the macros in alphabetical order.
+func (s *Shortcuts) MacroNames() []string {
+	return slices.Sorted(maps.Keys(s.macros))
+}
+
+// RemoveMacro removes the macro.
+func (s *Shortcuts) RemoveMacro(name string) error {
+	if _, ok := s.macros[name]; !ok {
+		return fmt.Errorf("%w: there is no macro called %q", ErrNotFound, name)
+	}
+
+	delete(s.macros, name)
+
+	return s.save()
+}
+
+// StartRecording starts recording the command lines for the macro.
+// An existing macro with the same name is replaced when the recording stops.
+func (s *Shortcuts) StartRecording(name string) error {
+	if s.recording != "" {
+		return fmt.Errorf("%w (%s)", ErrRecording, s.recording)
+	}
+
+	if _, ok := s.aliases[name]; ok {
+		return fmt.Errorf("%w by an alias", ErrNameTaken)
+	}
+
+	s.recording = name
+	s.recorded = make([]string, 0)
+
+	return nil
+}
+
+// Recording returns the name of the macro that is being recorded, if any.
+func (s *Shortcuts) Recording() (string, bool) {
+	return s.recording, s.recording != ""
+}
+
+// Record adds the command line to the macro that is being recorded.
+// It does nothing if no macro is being recorded.
+func (s *Shortcuts) Record(line string) {
+	if s.recording == "" {
+		return
+	}
+
+	s.recorded = append(s.recorded, line)
+}
+
+// StopRecording saves the macro that is being recorded and returns its name
+// and the number of recorded command lines. The macro is discarded if no
+// command lines were recorded.
+func (s *Shortcuts) StopRecording() (string, int, error) {
+	if s.recording == "" {
+		return "", 0, ErrNotRecording
+	}
+
+	name, lines := s.recording, s.recorded
+
+	s.recording, s.recorded = "", nil
+
+	if len(lines) == 0 {
+		return name, 0, nil
+	}
+
+	s.macros[name] = lines
+
+	return name, len(lines), s.save()
+}
+
+// Expand resolves the aliases and macros in the pipeline. An alias is
+// replaced by its command in any stage of the pipeline, and the arguments
+// that follow it are kept. A macro, including one named by an alias, is
+// replaced by the pipelines on its recorded command lines.
+func (s *Shortcuts) Expand(pipeline cmdline.Pipeline) ([]cmdline.Pipeline, error) {
+	return s.expand(pipeline, 0)
+}
+
+func (s *Shortcuts) expand(pipeline cmdline.Pipeline, depth int) ([]cmdline.Pipeline, error) {
+	if len(pipeline) == 0 || len(pipeline[0]) == 0 {
+		return []cmdline.Pipeline{pipeline}, nil
+	}
+
+	name := strings.ToLower(pipeline[0][0])
+
+	lines, ok := s.macros[name]
+	if !ok {
+		expanded := s.expandAliases(pipeline)
+
+		// An alias for a macro is expanded again to run the macro.
+		if len(expanded[0]) > 0 {
+			if _, ok := s.macros[strings.ToLower(expanded[0][0])]; ok {
+				return s.expand(expanded, depth+1)
+			}
+		}
+
+		return []cmdline.Pipeline{expanded}, nil
+	}
+
+	if len(pipeline) > 1 || len(pipeline[0]) > 1 {
+		return nil, fmt.Errorf("the %s macro does not take arguments and its output cannot be piped", name)
+	}
+
+	if depth >= maxMacroDepth {
+		return nil, fmt.Errorf("the %s macro runs too many nested macros", name)
+	}
+
+	expanded := make([]cmdline.Pipeline, 0, len(lines))
+
+	for _, line := range slices.All(lines) {
+		pipelines, err := cmdline.Parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %q in the %s macro: %w", line, name, err)
+		}
+
+		for _, pipeline := range slices.All(pipelines) {
+			nested, err := s.expand(pipeline, depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			expanded = append(expanded, nested...)
+		}
+	}
+
+	return expanded, nil
+}
+
+func (s *Shortcuts) expandAliases(pipeline cmdline.Pipeline) cmdline.Pipeline {
+	expanded := make(cmdline.Pipeline, len(pipeline))
+
+	for ind, stage := range slices.All(pipeline) {
+		words, ok := s.aliases[strings.ToLower(stage[0])]
+		if !ok {
+			expanded[ind] = stage
+
+			continue
+		}
+
+		expanded[ind] = append(slices.Clone(words), stage[1:]...)
+	}
+
+	return expanded
+}
+
+// save writes the shortcuts to the shortcuts file so that the file
+// is never left half written.
+func (s *Shortcuts) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(shortcutsFile{Aliases: s.aliases, Macros: s.macros}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode the shortcuts: %w", err)
+	}
+
+	if err := atomicfile.Write(s.path, data); err != nil {
+		return fmt.Errorf("unable to save the shortcuts: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package shortcuts_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/cmdline"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/shortcuts"
+)
+
+func TestShortcutsArePersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shortcuts.json")
+
+	saved, err := shortcuts.Load(path)
+	if err != nil {
+		t.Fatalf("Unable to load the shortcuts from a missing file: %v", err)
+	}
+
+	if err := saved.SetAlias("vi", []string{"visit", "iron-island-area"}); err != nil {
+		t.Fatalf("Unable to set the alias: %v", err)
+	}
+
+	if err := saved.StartRecording("hunt"); err != nil {
+		t.Fatalf("Unable to start recording the macro: %v", err)
+	}
+
+	saved.Record("vi")
+	saved.Record("explore | count")
+
+	if name, lines, err := saved.StopRecording(); err != nil || name != "hunt" || lines != 2 {
+		t.Fatalf("Unexpected result after recording the macro: want hunt, 2, <nil>; got %s, %d, %v", name, lines, err)
+	}
+
+	loaded, err := shortcuts.Load(path)
+	if err != nil {
+		t.Fatalf("Unable to load the saved shortcuts: %v", err)
+	}
+
+	if got, _ := loaded.Alias("vi"); !reflect.DeepEqual(got, []string{"visit", "iron-island-area"}) {
+		t.Errorf("Unexpected alias after loading the shortcuts: got %q", got)
+	}
+
+	if got, _ := loaded.Macro("hunt"); !reflect.DeepEqual(got, []string{"vi", "explore | count"}) {
+		t.Errorf("Unexpected macro after loading the shortcuts: got %q", got)
+	}
+
+	if err := loaded.RemoveAlias("vi"); err != nil {
+		t.Fatalf("Unable to remove the alias: %v", err)
+	}
+
+	if err := loaded.RemoveAlias("vi"); !errors.Is(err, shortcuts.ErrNotFound) {
+		t.Errorf("Unexpected error after removing a missing alias: want %v, got %v", shortcuts.ErrNotFound, err)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	shortcutSet := shortcuts.New()
+
+	if err := shortcutSet.SetAlias("vi", []string{"visit", "iron-island-area"}); err != nil {
+		t.Fatalf("Unable to set the alias: %v", err)
+	}
+
+	if err := shortcutSet.SetAlias("fire", []string{"grep", "--ignore-case"}); err != nil {
+		t.Fatalf("Unable to set the alias: %v", err)
+	}
+
+	if err := shortcutSet.StartRecording("hunt"); err != nil {
+		t.Fatalf("Unable to start recording the macro: %v", err)
+	}
+
+	shortcutSet.Record("vi; explore | fire onix")
+	shortcutSet.Record(`catch "mr. mime"`)
+
+	if _, _, err := shortcutSet.StopRecording(); err != nil {
+		t.Fatalf("Unable to stop recording the macro: %v", err)
+	}
+
+	if err := shortcutSet.SetAlias("h", []string{"hunt"}); err != nil {
+		t.Fatalf("Unable to set the alias: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		pipeline cmdline.Pipeline
+		want     []cmdline.Pipeline
+	}{
+		{
+			name:     "No shortcuts",
+			pipeline: cmdline.Pipeline{{"catch", "onix"}},
+			want:     []cmdline.Pipeline{{{"catch", "onix"}}},
+		},
+		{
+			name:     "Aliases in a pipeline",
+			pipeline: cmdline.Pipeline{{"pokedex"}, {"fire", "FIRE"}},
+			want:     []cmdline.Pipeline{{{"pokedex"}, {"grep", "--ignore-case", "FIRE"}}},
+		},
+		{
+			name:     "Macro",
+			pipeline: cmdline.Pipeline{{"hunt"}},
+			want: []cmdline.Pipeline{
+				{{"visit", "iron-island-area"}},
+				{{"explore"}, {"grep", "--ignore-case", "onix"}},
+				{{"catch", "mr. mime"}},
+			},
+		},
+		{
+			name:     "Alias for a macro",
+			pipeline: cmdline.Pipeline{{"H"}},
+			want: []cmdline.Pipeline{
+				{{"visit", "iron-island-area"}},
+				{{"explore"}, {"grep", "--ignore-case", "onix"}},
+				{{"catch", "mr. mime"}},
+			},
+		},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := shortcutSet.Expand(testcase.pipeline)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, testcase.want) {
+				t.Errorf("Unexpected pipelines: want %q, got %q", testcase.want, got)
+			}
+		})
+	}
+
+	if err := shortcutSet.StartRecording("loop"); err != nil {
+		t.Fatalf("Unable to start recording the macro: %v", err)
+	}
+
+	shortcutSet.Record("loop")
+
+	if _, _, err := shortcutSet.StopRecording(); err != nil {
+		t.Fatalf("Unable to stop recording the macro: %v", err)
+	}
+
+	if _, err := shortcutSet.Expand(cmdline.Pipeline{{"loop"}}); err == nil {
+		t.Error("Expected an error after expanding a macro that runs itself")
+	}
+
+	if err := shortcutSet.SetAlias("again", []string{"cycle"}); err != nil {
+		t.Fatalf("Unable to set the alias: %v", err)
+	}
+
+	if err := shortcutSet.StartRecording("cycle"); err != nil {
+		t.Fatalf("Unable to start recording the macro: %v", err)
+	}
+
+	shortcutSet.Record("again")
+
+	if _, _, err := shortcutSet.StopRecording(); err != nil {
+		t.Fatalf("Unable to stop recording the macro: %v", err)
+	}
+
+	if _, err := shortcutSet.Expand(cmdline.Pipeline{{"again"}}); err == nil {
+		t.Error("Expected an error after expanding an alias for a macro that runs the alias")
+	}
+
+	if _, err := shortcutSet.Expand(cmdline.Pipeline{{"h", "extra"}}); err == nil {
+		t.Error("Expected an error after passing arguments to a macro through an alias")
+	}
+}
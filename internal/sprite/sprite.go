@@ -0,0 +1,321 @@
+// Package sprite renders the images of Pokemon sprites as text for the terminal.
+//
+// Each character cell shows two pixels stacked on top of each other with the
+// upper half block character: the foreground colour is the upper pixel and the
+// background colour is the lower pixel. Terminals without colour support get
+// characters that get denser as the pixels get brighter instead.
+package sprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"slices"
+	"strings"
+)
+
+const (
+	upperHalfBlock = '▀'
+	lowerHalfBlock = '▄'
+
+	// alphaThreshold is the minimum opacity of a pixel that is drawn.
+	alphaThreshold = 0x8000
+)
+
+// densityRamp is used when colours are not supported. The characters range
+// from the darkest to the brightest pixels on a dark background.
+var densityRamp = []rune(".:-=+*#%@")
+
+// ColourMode is the set of colours that the terminal can display.
+type ColourMode int
+
+const (
+	ColourNone ColourMode = iota
+	Colour256
+	ColourTrueColour
+)
+
+// ColourModeFromEnv detects the colour mode from the environment variables.
+// NO_COLOR disables colours, COLORTERM announces truecolor support and
+// TERM announces support for 256 colours.
+func ColourModeFromEnv(getenv func(string) string) ColourMode {
+	if getenv("NO_COLOR") != "" {
+		return ColourNone
+	}
+
+	switch colorTerm := strings.ToLower(getenv("COLORTERM")); colorTerm {
+	case "truecolor", "24bit":
+		return ColourTrueColour
+	}
+
+	term := strings.ToLower(getenv("TERM"))
+
+	switch {
+	case term == "" || term == "dumb":
+		return ColourNone
+	case strings.Contains(term, "256color"), strings.Contains(term, "truecolor"):
+		return Colour256
+	case strings.HasPrefix(term, "xterm"), strings.HasPrefix(term, "screen"), strings.HasPrefix(term, "tmux"):
+		return Colour256
+	}
+
+	return ColourNone
+}
+
+// Cell is a character cell of a rendered sprite. The colours are nil
+// where the pixels are transparent or when colours are not used.
+type Cell struct {
+	Char       rune
+	Foreground color.Color
+	Background color.Color
+}
+
+// Cells downscales the image to fit within the width and height in character
+// cells and converts it into cells. The transparent border around the sprite
+// is cropped first so that the Pokemon fills as much of the space as possible.
+func Cells(img image.Image, maxWidth, maxHeight int, mode ColourMode) [][]Cell {
+	bounds := opaqueBounds(img)
+	if bounds.Empty() || maxWidth <= 0 || maxHeight <= 0 {
+		return nil
+	}
+
+	// A cell is twice as tall as it is wide so each cell
+	// holds a square of one pixel by two pixels.
+	width := min(maxWidth, bounds.Dx())
+	height := (bounds.Dy()*width + bounds.Dx() - 1) / bounds.Dx()
+
+	if height > maxHeight*2 {
+		height = maxHeight * 2
+		width = max(1, (bounds.Dx()*height)/bounds.Dy())
+	}
+
+	pixels := downscale(img, bounds, width, height)
+
+	rows := make([][]Cell, 0, (height+1)/2)
+
+	for y := 0; y < height; y += 2 {
+		row := make([]Cell, width)
+
+		for x := range width {
+			var upper, lower color.Color
+
+			upper = pixels[y][x]
+			if y+1 < height {
+				lower = pixels[y+1][x]
+			}
+
+			row[x] = cell(upper, lower, mode)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// Render renders the image as lines of text with the escape sequences
+// for the colour mode.
+func Render(img image.Image, maxWidth, maxHeight int, mode ColourMode) string {
+	var builder strings.Builder
+
+	for _, row := range slices.All(Cells(img, maxWidth, maxHeight, mode)) {
+		line := ""
+
+		for _, cell := range slices.All(row) {
+			line += Sequence(cell.Foreground, cell.Background, mode) + string(cell.Char)
+		}
+
+		if mode != ColourNone {
+			line += "\x1b[0m"
+		}
+
+		builder.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	return builder.String()
+}
+
+// Sequence returns the escape sequence that sets the foreground and
+// background colours. A nil colour resets it to the terminal's default.
+func Sequence(foreground, background color.Color, mode ColourMode) string {
+	if mode == ColourNone {
+		return ""
+	}
+
+	sequence := "\x1b[39;49m"
+
+	if foreground != nil {
+		sequence += "\x1b[38;" + colourParameters(foreground, mode) + "m"
+	}
+
+	if background != nil {
+		sequence += "\x1b[48;" + colourParameters(background, mode) + "m"
+	}
+
+	return sequence
+}
+
+func colourParameters(colour color.Color, mode ColourMode) string {
+	red, green, blue := rgb(colour)
+
+	if mode == ColourTrueColour {
+		return fmt.Sprintf("2;%d;%d;%d", red, green, blue)
+	}
+
+	return fmt.Sprintf("5;%d", xterm256(red, green, blue))
+}
+
+func cell(upper, lower color.Color, mode ColourMode) Cell {
+	if mode == ColourNone {
+		return Cell{Char: densityChar(upper, lower), Foreground: nil, Background: nil}
+	}
+
+	switch {
+	case upper == nil && lower == nil:
+		return Cell{Char: ' ', Foreground: nil, Background: nil}
+	case upper == nil:
+		return Cell{Char: lowerHalfBlock, Foreground: lower, Background: nil}
+	default:
+		return Cell{Char: upperHalfBlock, Foreground: upper, Background: lower}
+	}
+}
+
+// densityChar returns the character for the average brightness of the
+// opaque pixels, or a space if both pixels are transparent.
+func densityChar(upper, lower color.Color) rune {
+	var (
+		total  int
+		pixels int
+	)
+
+	for _, colour := range []color.Color{upper, lower} {
+		if colour == nil {
+			continue
+		}
+
+		total += int(color.GrayModel.Convert(colour).(color.Gray).Y)
+		pixels++
+	}
+
+	if pixels == 0 {
+		return ' '
+	}
+
+	return densityRamp[(total/pixels)*len(densityRamp)/256]
+}
+
+// opaqueBounds returns the smallest rectangle that contains all the opaque pixels.
+func opaqueBounds(img image.Image) image.Rectangle {
+	bounds := img.Bounds()
+	opaque := image.Rectangle{}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, alpha := img.At(x, y).RGBA(); alpha >= alphaThreshold {
+				opaque = opaque.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	return opaque
+}
+
+// downscale averages the pixels in the bounds into a grid of the given size.
+// The colours are weighted by their opacity so that the transparent pixels do
+// not darken the edges. A pixel in the grid is nil if it is mostly transparent.
+func downscale(img image.Image, bounds image.Rectangle, width, height int) [][]color.Color {
+	pixels := make([][]color.Color, height)
+
+	for row := range height {
+		pixels[row] = make([]color.Color, width)
+
+		top := bounds.Min.Y + row*bounds.Dy()/height
+		bottom := max(top+1, bounds.Min.Y+(row+1)*bounds.Dy()/height)
+
+		for col := range width {
+			left := bounds.Min.X + col*bounds.Dx()/width
+			right := max(left+1, bounds.Min.X+(col+1)*bounds.Dx()/width)
+
+			var red, green, blue, alpha, count uint64
+
+			for y := top; y < bottom; y++ {
+				for x := left; x < right; x++ {
+					// The colours are premultiplied by alpha.
+					r, g, b, a := img.At(x, y).RGBA()
+
+					red += uint64(r)
+					green += uint64(g)
+					blue += uint64(b)
+					alpha += uint64(a)
+					count++
+				}
+			}
+
+			if alpha/count < alphaThreshold {
+				continue
+			}
+
+			pixels[row][col] = color.RGBA{
+				R: uint8(red * 0xff / alpha),
+				G: uint8(green * 0xff / alpha),
+				B: uint8(blue * 0xff / alpha),
+				A: 0xff,
+			}
+		}
+	}
+
+	return pixels
+}
+
+func rgb(colour color.Color) (uint8, uint8, uint8) {
+	rgba := color.RGBAModel.Convert(colour).(color.RGBA)
+
+	return rgba.R, rgba.G, rgba.B
+}
+
+// xterm256 returns the closest colour in the xterm 256 colour palette, which has a
+// 6x6x6 colour cube from 16 to 231 and a ramp of greys from 232 to 255.
+func xterm256(red, green, blue uint8) int {
+	levels := []int{0, 95, 135, 175, 215, 255}
+
+	nearestLevel := func(value uint8) int {
+		nearest := 0
+
+		for ind, level := range slices.All(levels) {
+			if abs(int(value)-level) < abs(int(value)-levels[nearest]) {
+				nearest = ind
+			}
+		}
+
+		return nearest
+	}
+
+	cubeRed, cubeGreen, cubeBlue := nearestLevel(red), nearestLevel(green), nearestLevel(blue)
+	cubeIndex := 16 + 36*cubeRed + 6*cubeGreen + cubeBlue
+	cubeDistance := distance(red, green, blue, levels[cubeRed], levels[cubeGreen], levels[cubeBlue])
+
+	grey := (int(red) + int(green) + int(blue)) / 3
+	greyStep := max(0, min(23, (grey-8+5)/10))
+	greyLevel := 8 + greyStep*10
+	greyDistance := distance(red, green, blue, greyLevel, greyLevel, greyLevel)
+
+	if greyDistance < cubeDistance {
+		return 232 + greyStep
+	}
+
+	return cubeIndex
+}
+
+func distance(red, green, blue uint8, otherRed, otherGreen, otherBlue int) int {
+	dr, dg, db := int(red)-otherRed, int(green)-otherGreen, int(blue)-otherBlue
+
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,93 @@
+package sprite_test
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"strings"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/sprite"
+)
+
+// testImage returns a 16x16 image with a transparent border around a
+// 4x4 square, with the top half in red and the bottom half in blue.
+func testImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+
+	for y := 6; y < 10; y++ {
+		for x := 6; x < 10; x++ {
+			colour := color.NRGBA{R: 255, G: 0, B: 0, A: 255}
+			if y >= 8 {
+				colour = color.NRGBA{R: 0, G: 0, B: 255, A: 255}
+			}
+
+			img.Set(x, y, colour)
+		}
+	}
+
+	return img
+}
+
+func TestCells(t *testing.T) {
+	cells := sprite.Cells(testImage(), 40, 40, sprite.ColourTrueColour)
+
+	if len(cells) != 2 || len(cells[0]) != 4 {
+		t.Fatalf("Unexpected size of the cropped sprite: want 4x2 cells, got %dx%d", len(cells[0]), len(cells))
+	}
+
+	top, bottom := cells[0][0], cells[1][0]
+
+	if top.Char != '▀' || top.Foreground != (color.RGBA{R: 255, G: 0, B: 0, A: 255}) {
+		t.Errorf("Unexpected top cell: got %q with foreground %v", top.Char, top.Foreground)
+	}
+
+	if bottom.Background != (color.RGBA{R: 0, G: 0, B: 255, A: 255}) {
+		t.Errorf("Unexpected background of the bottom cell: got %v", bottom.Background)
+	}
+
+	if downscaled := sprite.Cells(testImage(), 2, 40, sprite.ColourTrueColour); len(downscaled) != 1 || len(downscaled[0]) != 2 {
+		t.Errorf("Unexpected size of the downscaled sprite: want 2x1 cells, got %v", downscaled)
+	}
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		name string
+		mode sprite.ColourMode
+		want string
+	}{
+		{name: "Truecolor", mode: sprite.ColourTrueColour, want: "\x1b[38;2;255;0;0m\x1b[48;2;255;0;0m▀"},
+		{name: "256 colours", mode: sprite.Colour256, want: "\x1b[38;5;196m\x1b[48;5;196m▀"},
+		{name: "No colours", mode: sprite.ColourNone, want: "----\n"},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		t.Run(testcase.name, func(t *testing.T) {
+			got := sprite.Render(testImage(), 40, 40, testcase.mode)
+			if !strings.Contains(got, testcase.want) {
+				t.Errorf("The rendered sprite does not contain %q:\n%q", testcase.want, got)
+			}
+		})
+	}
+}
+
+func TestColourModeFromEnv(t *testing.T) {
+	cases := []struct {
+		env  map[string]string
+		want sprite.ColourMode
+	}{
+		{env: map[string]string{"COLORTERM": "truecolor", "TERM": "xterm-256color"}, want: sprite.ColourTrueColour},
+		{env: map[string]string{"TERM": "xterm-256color"}, want: sprite.Colour256},
+		{env: map[string]string{"TERM": "dumb"}, want: sprite.ColourNone},
+		{env: map[string]string{"NO_COLOR": "1", "COLORTERM": "truecolor"}, want: sprite.ColourNone},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		getenv := func(name string) string { return testcase.env[name] }
+
+		if got := sprite.ColourModeFromEnv(getenv); got != testcase.want {
+			t.Errorf("Unexpected colour mode for %v: want %d, got %d", testcase.env, testcase.want, got)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package tui
+
+import "unicode/utf8"
+
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyTab
+	keyBackTab
+	keyEnter
+	keyEscape
+	keyCtrlC
+	keyCtrlD
+)
+
+// key is a key press read from the terminal. The rune is only set for keyRune.
+type key struct {
+	code keyCode
+	char rune
+}
+
+// escapeSequences maps the escape sequences sent by the terminal, without the
+// leading escape character, to their keys.
+var escapeSequences = map[string]keyCode{
+	"[A":  keyUp,
+	"[B":  keyDown,
+	"[C":  keyRight,
+	"[D":  keyLeft,
+	"OA":  keyUp,
+	"OB":  keyDown,
+	"OC":  keyRight,
+	"OD":  keyLeft,
+	"[5~": keyPageUp,
+	"[6~": keyPageDown,
+	"[H":  keyHome,
+	"[F":  keyEnd,
+	"[1~": keyHome,
+	"[4~": keyEnd,
+	"[Z":  keyBackTab,
+}
+
+// parseKeys converts the bytes read from the terminal into key presses.
+// Unknown escape sequences are ignored.
+func parseKeys(data []byte) []key {
+	keys := make([]key, 0, len(data))
+
+	for len(data) > 0 {
+		switch data[0] {
+		case '\x1b':
+			code, length, ok := parseEscapeSequence(data[1:])
+
+			switch {
+			case ok:
+				keys = append(keys, key{code: code, char: 0})
+			case length == 0:
+				keys = append(keys, key{code: keyEscape, char: 0})
+			}
+
+			data = data[1+length:]
+
+			continue
+		case '\r', '\n':
+			keys = append(keys, key{code: keyEnter, char: 0})
+		case '\t':
+			keys = append(keys, key{code: keyTab, char: 0})
+		case 0x03:
+			keys = append(keys, key{code: keyCtrlC, char: 0})
+		case 0x04:
+			keys = append(keys, key{code: keyCtrlD, char: 0})
+		default:
+			char, size := utf8.DecodeRune(data)
+			if char != utf8.RuneError && char >= ' ' && char != 0x7f {
+				keys = append(keys, key{code: keyRune, char: char})
+			}
+
+			data = data[size:]
+
+			continue
+		}
+
+		data = data[1:]
+	}
+
+	return keys
+}
+
+// parseEscapeSequence returns the key for the escape sequence at the start of
+// the data and its length. The length is zero if the data does not start
+// with an escape sequence, i.e. the escape key was pressed on its own.
+func parseEscapeSequence(data []byte) (keyCode, int, bool) {
+	if len(data) == 0 || (data[0] != '[' && data[0] != 'O') {
+		return 0, 0, false
+	}
+
+	// The sequence ends at the first letter or tilde after the opening bracket.
+	for ind := 1; ind < len(data); ind++ {
+		char := data[ind]
+		if char == '~' || (char >= 'A' && char <= 'Z') || (char >= 'a' && char <= 'z') {
+			code, ok := escapeSequences[string(data[:ind+1])]
+
+			return code, ind + 1, ok
+		}
+	}
+
+	return 0, len(data), false
+}
@@ -0,0 +1,58 @@
+package tui
+
+import "slices"
+
+// list is a scrollable list of names with a selected item.
+type list struct {
+	items    []string
+	selected int
+	offset   int
+}
+
+// set replaces the items and keeps the selected item selected if it is
+// still in the list.
+func (l *list) set(items []string) {
+	current, ok := l.current()
+
+	l.items = items
+
+	if ok {
+		if ind := slices.Index(items, current); ind >= 0 {
+			l.selected = ind
+
+			return
+		}
+	}
+
+	l.move(0)
+}
+
+func (l *list) current() (string, bool) {
+	if l.selected < 0 || l.selected >= len(l.items) {
+		return "", false
+	}
+
+	return l.items[l.selected], true
+}
+
+func (l *list) move(delta int) {
+	l.selected = max(0, min(l.selected+delta, len(l.items)-1))
+}
+
+// scroll moves the offset so that the selected item is visible in a
+// pane with the given height.
+func (l *list) scroll(height int) {
+	if height <= 0 {
+		return
+	}
+
+	if l.selected < l.offset {
+		l.offset = l.selected
+	}
+
+	if l.selected >= l.offset+height {
+		l.offset = l.selected - height + 1
+	}
+
+	l.offset = max(0, min(l.offset, len(l.items)-height))
+}
@@ -0,0 +1,197 @@
+package tui
+
+import (
+	"image/color"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/sprite"
+)
+
+const (
+	enterAlternateScreen = "\x1b[?1049h"
+	exitAlternateScreen  = "\x1b[?1049l"
+	hideCursor           = "\x1b[?25l"
+	showCursor           = "\x1b[?25h"
+	resetStyle           = "\x1b[0m"
+)
+
+type style uint8
+
+const (
+	styleBold style = 1 << iota
+	styleDim
+	styleReverse
+)
+
+type cell struct {
+	char       rune
+	style      style
+	foreground color.Color
+	background color.Color
+}
+
+// screen is a grid of styled cells that is drawn to the terminal in one write.
+type screen struct {
+	width  int
+	height int
+	cells  []cell
+}
+
+func newScreen(width, height int) *screen {
+	cells := make([]cell, width*height)
+
+	for ind := range cells {
+		cells[ind] = cell{char: ' ', style: 0, foreground: nil, background: nil}
+	}
+
+	return &screen{
+		width:  width,
+		height: height,
+		cells:  cells,
+	}
+}
+
+func (s *screen) set(x, y int, char rune, cellStyle style) {
+	if x < 0 || y < 0 || x >= s.width || y >= s.height {
+		return
+	}
+
+	s.cells[y*s.width+x] = cell{char: char, style: cellStyle, foreground: nil, background: nil}
+}
+
+// sprite draws the cells of a sprite with its top left corner at the position.
+func (s *screen) sprite(x, y int, rows [][]sprite.Cell) {
+	for row, cells := range slices.All(rows) {
+		for col, spriteCell := range slices.All(cells) {
+			if x+col >= s.width || y+row >= s.height {
+				continue
+			}
+
+			s.cells[(y+row)*s.width+x+col] = cell{
+				char:       spriteCell.Char,
+				style:      0,
+				foreground: spriteCell.Foreground,
+				background: spriteCell.Background,
+			}
+		}
+	}
+}
+
+// text writes the text at the position, truncating it at the maximum width.
+// It returns the number of cells that were written.
+func (s *screen) text(x, y, maxWidth int, text string, cellStyle style) int {
+	written := 0
+
+	for _, char := range text {
+		if written >= maxWidth {
+			break
+		}
+
+		s.set(x+written, y, char, cellStyle)
+		written++
+	}
+
+	return written
+}
+
+// fill sets the style of a row of cells and pads them with spaces.
+func (s *screen) fill(x, y, width int, cellStyle style) {
+	for ind := range width {
+		s.set(x+ind, y, ' ', cellStyle)
+	}
+}
+
+// box draws a border around the area with the title in the top border.
+func (s *screen) box(x, y, width, height int, title string, focused bool) {
+	if width < 2 || height < 2 {
+		return
+	}
+
+	borderStyle := styleDim
+	if focused {
+		borderStyle = styleBold
+	}
+
+	for col := x + 1; col < x+width-1; col++ {
+		s.set(col, y, '─', borderStyle)
+		s.set(col, y+height-1, '─', borderStyle)
+	}
+
+	for row := y + 1; row < y+height-1; row++ {
+		s.set(x, row, '│', borderStyle)
+		s.set(x+width-1, row, '│', borderStyle)
+	}
+
+	s.set(x, y, '┌', borderStyle)
+	s.set(x+width-1, y, '┐', borderStyle)
+	s.set(x, y+height-1, '└', borderStyle)
+	s.set(x+width-1, y+height-1, '┘', borderStyle)
+
+	if title != "" {
+		s.text(x+2, y, width-4, " "+title+" ", borderStyle)
+	}
+}
+
+// lines returns the text on the screen without the styles.
+func (s *screen) lines() []string {
+	lines := make([]string, s.height)
+
+	for row := range s.height {
+		var builder strings.Builder
+
+		for _, cell := range s.cells[row*s.width : (row+1)*s.width] {
+			builder.WriteRune(cell.char)
+		}
+
+		lines[row] = strings.TrimRight(builder.String(), " ")
+	}
+
+	return lines
+}
+
+// render returns the escape sequences and text that draw the screen
+// over the whole terminal.
+func (s *screen) render(mode sprite.ColourMode) string {
+	var builder strings.Builder
+
+	for row := range s.height {
+		builder.WriteString("\x1b[" + strconv.Itoa(row+1) + ";1H")
+
+		current := cell{char: 0, style: 0, foreground: nil, background: nil}
+
+		for _, next := range s.cells[row*s.width : (row+1)*s.width] {
+			if next.style != current.style || next.foreground != current.foreground || next.background != current.background {
+				builder.WriteString(styleSequence(next.style))
+				builder.WriteString(sprite.Sequence(next.foreground, next.background, mode))
+
+				current = next
+			}
+
+			builder.WriteRune(next.char)
+		}
+
+		builder.WriteString(resetStyle)
+	}
+
+	return builder.String()
+}
+
+func styleSequence(cellStyle style) string {
+	sequence := resetStyle
+
+	if cellStyle&styleBold != 0 {
+		sequence += "\x1b[1m"
+	}
+
+	if cellStyle&styleDim != 0 {
+		sequence += "\x1b[2m"
+	}
+
+	if cellStyle&styleReverse != 0 {
+		sequence += "\x1b[7m"
+	}
+
+	return sequence
+}
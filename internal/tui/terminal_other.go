@@ -0,0 +1,19 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package tui
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+func makeRaw(_ *os.File) (func() error, error) {
+	return nil, fmt.Errorf("the terminal UI is not supported on %s", runtime.GOOS)
+}
+
+func terminalSize(_ *os.File) (int, int, error) {
+	return 0, 0, fmt.Errorf("the terminal UI is not supported on %s", runtime.GOOS)
+}
+
+func notifyResize(_ chan<- os.Signal) {}
@@ -0,0 +1,76 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+type winsize struct {
+	rows   uint16
+	cols   uint16
+	xPixel uint16
+	yPixel uint16
+}
+
+// makeRaw puts the terminal into raw mode so that every key press is read
+// as soon as it is typed and is not echoed back. The returned function
+// restores the terminal to its previous state.
+func makeRaw(file *os.File) (func() error, error) {
+	fd := file.Fd()
+
+	var original syscall.Termios
+
+	if err := ioctl(fd, ioctlGetTermios, unsafe.Pointer(&original)); err != nil {
+		return nil, fmt.Errorf("unable to get the terminal settings: %w", err)
+	}
+
+	raw := original
+
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if err := ioctl(fd, ioctlSetTermios, unsafe.Pointer(&raw)); err != nil {
+		return nil, fmt.Errorf("unable to put the terminal into raw mode: %w", err)
+	}
+
+	restore := func() error {
+		return ioctl(fd, ioctlSetTermios, unsafe.Pointer(&original))
+	}
+
+	return restore, nil
+}
+
+// terminalSize returns the number of columns and rows of the terminal.
+func terminalSize(file *os.File) (int, int, error) {
+	var size winsize
+
+	if err := ioctl(file.Fd(), syscall.TIOCGWINSZ, unsafe.Pointer(&size)); err != nil {
+		return 0, 0, fmt.Errorf("unable to get the size of the terminal: %w", err)
+	}
+
+	return int(size.cols), int(size.rows), nil
+}
+
+// notifyResize relays the signals sent when the terminal is resized.
+func notifyResize(resized chan<- os.Signal) {
+	signal.Notify(resized, syscall.SIGWINCH)
+}
+
+func ioctl(fd, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+
+	return nil
+}
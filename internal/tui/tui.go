@@ -0,0 +1,155 @@
+// Package tui is a full-screen terminal UI for browsing the location areas,
+// the Pokemon that can be encountered in them and the trainer's Pokedex.
+//
+// The terminal is put into raw mode with the termios ioctls so that only the
+// standard library is needed. The UI runs the visit, catch, inspect and
+// release commands through a Runner so that it shares their logic with the REPL.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/sprite"
+)
+
+// Runner runs the command with the arguments and writes its output to out.
+type Runner func(ctx context.Context, out io.Writer, args []string) error
+
+// Run runs the UI in the terminal attached to the standard input and output
+// until the user quits.
+func Run(client *pokeclient.Client, trainer *poketrainer.Trainer, runner Runner) error {
+	input, output := os.Stdin, os.Stdout
+
+	width, height, err := terminalSize(output)
+	if err != nil {
+		return err
+	}
+
+	restore, err := makeRaw(input)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = restore()
+	}()
+
+	fmt.Fprint(output, enterAlternateScreen+hideCursor)
+	defer fmt.Fprint(output, resetStyle+showCursor+exitAlternateScreen)
+
+	keys := make(chan []key)
+	go readKeys(input, keys)
+
+	resized := make(chan os.Signal, 1)
+	notifyResize(resized)
+
+	defer signal.Stop(resized)
+
+	var (
+		state  = newUI(client, trainer, runner, sprite.ColourModeFromEnv(os.Getenv))
+		cancel = context.CancelFunc(func() {})
+
+		// Only one action runs at a time, so the buffer lets a running action
+		// send its update and finish even after the UI has stopped reading.
+		updates = make(chan update, 1)
+	)
+
+	defer func() { cancel() }()
+
+	start := func(act action) {
+		var ctx context.Context
+
+		ctx, cancel = context.WithCancel(context.Background())
+		state.busy = true
+
+		go func() {
+			updates <- act(ctx)
+		}()
+	}
+
+	start(state.initialise())
+
+	for !state.quit {
+		fmt.Fprint(output, state.view(width, height).render(state.colourMode))
+
+		select {
+		case pressed, ok := <-keys:
+			if !ok {
+				cancel()
+
+				return nil
+			}
+
+			for _, key := range pressed {
+				if state.busy {
+					if key.code == keyEscape || key.code == keyCtrlC {
+						cancel()
+					}
+
+					continue
+				}
+
+				if act := state.handleKey(key); act != nil {
+					start(act)
+				}
+
+				if state.quit {
+					break
+				}
+			}
+		case apply := <-updates:
+			cancel()
+
+			state.busy = false
+			apply(state)
+		case <-resized:
+			if newWidth, newHeight, err := terminalSize(output); err == nil {
+				width, height = newWidth, newHeight
+			}
+		}
+	}
+
+	return nil
+}
+
+// initialise loads the first page of location areas and the Pokemon in the
+// location area that the trainer is visiting.
+func (u *ui) initialise() action {
+	loadPage := u.loadPage(u.client.ListURL(pokeclient.LocationAreaResource.Path), 1)
+	loadEncounters := u.loadEncounters(u.location)
+
+	return func(ctx context.Context) update {
+		pageLoaded := loadPage(ctx)
+		encountersLoaded := loadEncounters(ctx)
+
+		return func(u *ui) {
+			encountersLoaded(u)
+			pageLoaded(u)
+		}
+	}
+}
+
+// readKeys sends the keys pressed in the terminal to the channel until
+// the input is closed.
+func readKeys(input io.Reader, keys chan<- []key) {
+	defer close(keys)
+
+	buf := make([]byte, 256)
+
+	for {
+		n, err := input.Read(buf)
+		if n > 0 {
+			keys <- parseKeys(buf[:n])
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
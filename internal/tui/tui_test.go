@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeapitest"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/sprite"
+)
+
+func TestParseKeys(t *testing.T) {
+	cases := []struct {
+		input string
+		want  []key
+	}{
+		{input: "jq", want: []key{{code: keyRune, char: 'j'}, {code: keyRune, char: 'q'}}},
+		{input: "\x1b[A\x1bOB", want: []key{{code: keyUp}, {code: keyDown}}},
+		{input: "\x1b[6~\x1b[Z\t\r", want: []key{{code: keyPageDown}, {code: keyBackTab}, {code: keyTab}, {code: keyEnter}}},
+		{input: "\x1b", want: []key{{code: keyEscape}}},
+		{input: "\x1b[99~\x03", want: []key{{code: keyCtrlC}}},
+	}
+
+	for _, testcase := range slices.All(cases) {
+		if got := parseKeys([]byte(testcase.input)); !reflect.DeepEqual(got, testcase.want) {
+			t.Errorf("Unexpected keys after parsing %q: want %v, got %v", testcase.input, testcase.want, got)
+		}
+	}
+}
+
+func TestListScroll(t *testing.T) {
+	items := list{items: []string{"a", "b", "c", "d", "e", "f"}, selected: 0, offset: 0}
+
+	items.move(4)
+	items.scroll(3)
+
+	if items.selected != 4 || items.offset != 2 {
+		t.Errorf("Unexpected position after moving down: want selected 4 and offset 2, got %d and %d", items.selected, items.offset)
+	}
+
+	items.move(10)
+
+	if item, _ := items.current(); item != "f" {
+		t.Errorf("Unexpected item after moving past the end of the list: want f, got %s", item)
+	}
+
+	items.set([]string{"f", "g"})
+
+	if item, _ := items.current(); item != "f" {
+		t.Errorf("Unexpected item after replacing the items: want f, got %s", item)
+	}
+}
+
+func TestVisitAndCatch(t *testing.T) {
+	server := pokeapitest.NewServer(t)
+	server.HandleJSON(
+		"/api/v2/location-area",
+		`{"count": 2, "next": null, "previous": null, "results": [{"name": "canalave-city-area"}, {"name": "eterna-city-area"}]}`,
+	)
+	server.HandleJSON(
+		"/api/v2/location-area/eterna-city-area/",
+		`{"name": "eterna-city-area", "pokemon_encounters": [{"pokemon": {"name": "psyduck"}}, {"pokemon": {"name": "golduck"}}]}`,
+	)
+
+	client := pokeclient.NewClient(time.Minute, 5*time.Second, pokeclient.WithBaseURL(server.URL))
+	defer client.Close()
+
+	trainer := poketrainer.NewTrainer()
+
+	var ran [][]string
+
+	// The runner stands in for the commands by updating the trainer directly.
+	runner := func(_ context.Context, out io.Writer, args []string) error {
+		ran = append(ran, args)
+
+		switch args[0] {
+		case "visit":
+			locationArea, err := client.GetLocationArea(context.Background(), args[1])
+			if err != nil {
+				return err
+			}
+
+			if err := trainer.UpdateCurrentLocationArea(locationArea); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(out, "You are now visiting", args[1])
+		case "catch":
+			return fmt.Errorf("%s escaped", args[1])
+		}
+
+		return nil
+	}
+
+	state := newUI(client, trainer, runner, sprite.ColourNone)
+
+	perform := func(act action) {
+		t.Helper()
+
+		if act == nil {
+			t.Fatal("The key press did not start an action")
+		}
+
+		act(context.Background())(state)
+	}
+
+	perform(state.initialise())
+
+	if want := []string{"canalave-city-area", "eterna-city-area"}; !reflect.DeepEqual(state.locations.items, want) {
+		t.Fatalf("Unexpected location areas: want %v, got %v", want, state.locations.items)
+	}
+
+	state.handleKey(key{code: keyDown})
+	perform(state.handleKey(key{code: keyEnter}))
+
+	if state.location != "eterna-city-area" {
+		t.Errorf("Unexpected location after visiting: want eterna-city-area, got %q", state.location)
+	}
+
+	if want := []string{"psyduck", "golduck"}; !reflect.DeepEqual(state.encounters.items, want) {
+		t.Errorf("Unexpected encounters after visiting: want %v, got %v", want, state.encounters.items)
+	}
+
+	state.handleKey(key{code: keyTab})
+	state.handleKey(key{code: keyDown})
+	perform(state.handleKey(key{code: keyRune, char: 'c'}))
+
+	if want := [][]string{{"visit", "eterna-city-area"}, {"catch", "golduck"}}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("Unexpected commands: want %v, got %v", want, ran)
+	}
+
+	if want := "ERROR: golduck escaped."; state.status != want {
+		t.Errorf("Unexpected status: want %q, got %q", want, state.status)
+	}
+
+	screen := strings.Join(state.view(100, 24).lines(), "\n")
+
+	for _, want := range []string{"Visiting eterna-city-area", "> eterna-city-area", "> golduck", "Pokedex (0)", "catch golduck"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("The screen does not contain %q:\n%s", want, screen)
+		}
+	}
+}
@@ -0,0 +1,506 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"slices"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/cmdline"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/pokeclient"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/poketrainer"
+	"codeflow.dananglin.me.uk/apollo/pokecli/internal/sprite"
+)
+
+const (
+	minWidth  = 60
+	minHeight = 16
+
+	// minSpriteWidth is the minimum width of the detail pane
+	// for the sprite to be drawn next to the text.
+	minSpriteWidth = 80
+	spriteWidth    = 24
+)
+
+type pane int
+
+const (
+	locationsPane pane = iota
+	encountersPane
+	pokedexPane
+	numPanes
+)
+
+// update changes the state of the UI with the result of an action. Updates
+// are applied on the UI's goroutine so that the state is never shared.
+type update func(u *ui)
+
+// action does the slow work for a key press, such as sending requests to the
+// PokeAPI or running a command, away from the UI's goroutine.
+type action func(ctx context.Context) update
+
+// ui is the state of the terminal UI.
+type ui struct {
+	client     *pokeclient.Client
+	trainer    *poketrainer.Trainer
+	run        Runner
+	colourMode sprite.ColourMode
+
+	focus      pane
+	locations  list
+	encounters list
+	pokedex    list
+
+	page     int
+	next     *string
+	previous *string
+	location string
+
+	detailTitle  string
+	detail       []string
+	detailOffset int
+	detailHeight int
+	detailSprite image.Image
+
+	status string
+	busy   bool
+	quit   bool
+}
+
+func newUI(client *pokeclient.Client, trainer *poketrainer.Trainer, runner Runner, colourMode sprite.ColourMode) *ui {
+	return &ui{
+		client:       client,
+		trainer:      trainer,
+		run:          runner,
+		colourMode:   colourMode,
+		focus:        locationsPane,
+		locations:    list{items: nil, selected: 0, offset: 0},
+		encounters:   list{items: nil, selected: 0, offset: 0},
+		pokedex:      list{items: trainer.PokedexNames(), selected: 0, offset: 0},
+		page:         0,
+		next:         nil,
+		previous:     nil,
+		location:     trainer.CurrentLocationAreaName(),
+		detailTitle:  "Help",
+		detail:       helpLines(),
+		detailOffset: 0,
+		detailHeight: 0,
+		detailSprite: nil,
+		status:       "",
+		busy:         false,
+		quit:         false,
+	}
+}
+
+func helpLines() []string {
+	return []string{
+		"Tab, Shift+Tab, Left, Right  Switch between the panes",
+		"Up, Down, j, k, Home, End    Move through the list",
+		"PgUp, PgDn                   Scroll this pane",
+		"n, p                         Display the next or previous page of location areas",
+		"Enter                        Visit, catch or inspect the selected item",
+		"v                            Visit the selected location area",
+		"c                            Catch the selected Pokemon in the location area",
+		"i                            Inspect the selected Pokemon in your Pokedex",
+		"r                            Release the selected Pokemon in your Pokedex",
+		"?                            Display this help",
+		"Esc                          Cancel the running action",
+		"q, Ctrl+C                    Quit",
+	}
+}
+
+// handleKey changes the state of the UI after the key press and returns
+// the action to run, if any.
+func (u *ui) handleKey(pressed key) action {
+	u.status = ""
+
+	switch pressed.code {
+	case keyCtrlC, keyCtrlD:
+		u.quit = true
+	case keyTab, keyRight:
+		u.focus = (u.focus + 1) % numPanes
+	case keyBackTab, keyLeft:
+		u.focus = (u.focus + numPanes - 1) % numPanes
+	case keyUp:
+		u.focusedList().move(-1)
+	case keyDown:
+		u.focusedList().move(1)
+	case keyHome:
+		u.focusedList().move(-len(u.focusedList().items))
+	case keyEnd:
+		u.focusedList().move(len(u.focusedList().items))
+	case keyPageUp:
+		u.scrollDetail(-max(1, u.detailHeight-1))
+	case keyPageDown:
+		u.scrollDetail(max(1, u.detailHeight-1))
+	case keyEnter:
+		switch u.focus {
+		case locationsPane:
+			return u.visitSelected()
+		case encountersPane:
+			return u.catchSelected()
+		case pokedexPane, numPanes:
+			return u.inspectSelected()
+		}
+	case keyRune:
+		return u.handleRune(pressed.char)
+	case keyEscape:
+	}
+
+	return nil
+}
+
+func (u *ui) handleRune(char rune) action {
+	switch char {
+	case 'q':
+		u.quit = true
+	case 'j':
+		u.focusedList().move(1)
+	case 'k':
+		u.focusedList().move(-1)
+	case 'n':
+		if u.next == nil {
+			u.status = "There are no more location areas."
+
+			return nil
+		}
+
+		return u.loadPage(*u.next, u.page+1)
+	case 'p':
+		if u.previous == nil {
+			u.status = "There are no previous location areas."
+
+			return nil
+		}
+
+		return u.loadPage(*u.previous, u.page-1)
+	case 'v':
+		return u.visitSelected()
+	case 'c':
+		return u.catchSelected()
+	case 'i':
+		return u.inspectSelected()
+	case 'r':
+		return u.releaseSelected()
+	case '?':
+		u.showDetail("Help", helpLines())
+	}
+
+	return nil
+}
+
+func (u *ui) focusedList() *list {
+	switch u.focus {
+	case encountersPane:
+		return &u.encounters
+	case pokedexPane:
+		return &u.pokedex
+	case locationsPane, numPanes:
+	}
+
+	return &u.locations
+}
+
+func (u *ui) visitSelected() action {
+	name, ok := u.locations.current()
+	if !ok {
+		u.status = "Select a location area to visit."
+
+		return nil
+	}
+
+	return u.runCommand("visit", name)
+}
+
+func (u *ui) catchSelected() action {
+	name, ok := u.encounters.current()
+	if !ok {
+		u.status = "Select a Pokemon in the location area to catch."
+
+		return nil
+	}
+
+	return u.runCommand("catch", name)
+}
+
+func (u *ui) inspectSelected() action {
+	name, ok := u.pokedex.current()
+	if !ok {
+		u.status = "Select a Pokemon in your Pokedex to inspect."
+
+		return nil
+	}
+
+	return u.runCommand("inspect", name)
+}
+
+func (u *ui) releaseSelected() action {
+	name, ok := u.pokedex.current()
+	if !ok {
+		u.status = "Select a Pokemon in your Pokedex to release."
+
+		return nil
+	}
+
+	return u.runCommand("release", name)
+}
+
+func (u *ui) showDetail(title string, lines []string) {
+	u.detailTitle = title
+	u.detail = lines
+	u.detailOffset = 0
+	u.detailSprite = nil
+}
+
+func (u *ui) scrollDetail(delta int) {
+	u.detailOffset = max(0, min(u.detailOffset+delta, len(u.detail)-u.detailHeight))
+}
+
+// loadPage fetches the page of location areas.
+func (u *ui) loadPage(url string, page int) action {
+	client := u.client
+
+	return func(ctx context.Context) update {
+		list, err := client.GetNamedAPIResourceList(ctx, url)
+		if err != nil {
+			return failed(ctx, err)
+		}
+
+		names := make([]string, len(list.Results))
+
+		for ind, result := range slices.All(list.Results) {
+			names[ind] = result.Name
+		}
+
+		pokeclient.Prefetch(client, pokeclient.LocationAreaResource, names...)
+
+		return func(u *ui) {
+			u.locations.set(names)
+			u.locations.selected, u.locations.offset = 0, 0
+			u.page, u.next, u.previous = page, list.Next, list.Previous
+		}
+	}
+}
+
+// loadEncounters fetches the Pokemon that can be found in the location area.
+func (u *ui) loadEncounters(location string) action {
+	client := u.client
+
+	return func(ctx context.Context) update {
+		names, err := encounterNames(ctx, client, location)
+		if err != nil {
+			return failed(ctx, err)
+		}
+
+		return func(u *ui) {
+			u.encounters.set(names)
+		}
+	}
+}
+
+// runCommand runs the command with the runner so that the UI shares the
+// commands' logic, and refreshes the panes that the command may have changed.
+func (u *ui) runCommand(args ...string) action {
+	client, trainer, runner := u.client, u.trainer, u.run
+
+	return func(ctx context.Context) update {
+		var output bytes.Buffer
+
+		err := runner(ctx, &output, args)
+
+		// The trainer is only read here, after the command has finished,
+		// because the command runs on this goroutine.
+		pokedex := trainer.PokedexNames()
+		location := trainer.CurrentLocationAreaName()
+
+		var (
+			encounters    []string
+			encountersErr error
+		)
+
+		if err == nil && args[0] == "visit" {
+			encounters, encountersErr = encounterNames(ctx, client, location)
+		}
+
+		var pokemonSprite image.Image
+
+		if err == nil && args[0] == "inspect" {
+			pokemonSprite = inspectedSprite(ctx, client, trainer, args[1])
+		}
+
+		return func(u *ui) {
+			u.showDetail(cmdline.Join(args), strings.Split(strings.TrimRight(output.String(), "\n"), "\n"))
+			u.detailSprite = pokemonSprite
+			u.pokedex.set(pokedex)
+
+			if location != u.location {
+				u.location = location
+				u.encounters.set(encounters)
+				u.encounters.selected, u.encounters.offset = 0, 0
+			}
+
+			switch {
+			case ctx.Err() != nil:
+				u.status = "The command was cancelled."
+			case err != nil:
+				u.status = errorStatus(err)
+			case encountersErr != nil:
+				u.status = errorStatus(encountersErr)
+			}
+		}
+	}
+}
+
+// inspectedSprite returns the default sprite of the Pokemon in the trainer's
+// Pokedex. The sprite is left out of the detail pane if it cannot be downloaded.
+func inspectedSprite(ctx context.Context, client *pokeclient.Client, trainer *poketrainer.Trainer, name string) image.Image {
+	pokemon, ok := trainer.GetPokemonFromPokedex(name)
+	if !ok {
+		return nil
+	}
+
+	img, err := client.GetSprite(ctx, pokemon.Sprites.FrontDefault)
+	if err != nil {
+		return nil
+	}
+
+	return img
+}
+
+func encounterNames(ctx context.Context, client *pokeclient.Client, location string) ([]string, error) {
+	if location == "" {
+		return nil, nil
+	}
+
+	locationArea, err := client.GetLocationArea(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the location area: %w", err)
+	}
+
+	names := make([]string, len(locationArea.PokemonEncounters))
+
+	for ind, encounter := range slices.All(locationArea.PokemonEncounters) {
+		names[ind] = encounter.Pokemon.Name
+	}
+
+	return names, nil
+}
+
+func failed(ctx context.Context, err error) update {
+	return func(u *ui) {
+		if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			u.status = "The action was cancelled."
+
+			return
+		}
+
+		u.status = errorStatus(err)
+	}
+}
+
+func errorStatus(err error) string {
+	return fmt.Sprintf("ERROR: %v.", err)
+}
+
+// view draws the UI on a screen of the given size.
+func (u *ui) view(width, height int) *screen {
+	screen := newScreen(width, height)
+
+	if width < minWidth || height < minHeight {
+		screen.text(0, 0, width, fmt.Sprintf("The terminal is too small (minimum %dx%d).", minWidth, minHeight), 0)
+
+		return screen
+	}
+
+	location := u.location
+	if location == "" {
+		location = "nowhere"
+	}
+
+	screen.fill(0, 0, width, styleReverse)
+	screen.text(1, 0, width-2, "pokecli | Visiting "+location, styleReverse)
+
+	listHeight := (height - 2) / 2
+	firstWidth, secondWidth := width/3, width/3
+	thirdWidth := width - firstWidth - secondWidth
+
+	locationsTitle := "Locations"
+	if u.page > 0 {
+		locationsTitle = fmt.Sprintf("Locations (page %d)", u.page)
+	}
+
+	u.drawList(screen, &u.locations, 0, 1, firstWidth, listHeight, locationsTitle, locationsPane)
+	u.drawList(screen, &u.encounters, firstWidth, 1, secondWidth, listHeight, "Encounters", encountersPane)
+	u.drawList(
+		screen,
+		&u.pokedex,
+		firstWidth+secondWidth,
+		1,
+		thirdWidth,
+		listHeight,
+		fmt.Sprintf("Pokedex (%d)", len(u.pokedex.items)),
+		pokedexPane,
+	)
+
+	detailTop := 1 + listHeight
+	detailHeight := height - 1 - detailTop
+
+	u.detailHeight = detailHeight - 2
+	u.scrollDetail(0)
+
+	screen.box(0, detailTop, width, detailHeight, u.detailTitle, false)
+
+	textLeft := 2
+
+	if u.detailSprite != nil && width >= minSpriteWidth {
+		screen.sprite(2, detailTop+1, sprite.Cells(u.detailSprite, spriteWidth, u.detailHeight, u.colourMode))
+
+		textLeft += spriteWidth + 2
+	}
+
+	for row := 0; row < u.detailHeight && u.detailOffset+row < len(u.detail); row++ {
+		screen.text(textLeft, detailTop+1+row, width-textLeft-2, u.detail[u.detailOffset+row], 0)
+	}
+
+	status := u.status
+
+	switch {
+	case u.busy:
+		status = "Working... (Esc to cancel)"
+	case status == "":
+		status = "Tab: switch pane  Enter: select  n/p: page  c: catch  r: release  ?: help  q: quit"
+	}
+
+	screen.text(1, height-1, width-2, status, styleDim)
+
+	return screen
+}
+
+func (u *ui) drawList(screen *screen, items *list, x, y, width, height int, title string, listPane pane) {
+	focused := u.focus == listPane
+
+	screen.box(x, y, width, height, title, focused)
+
+	innerHeight := height - 2
+	items.scroll(innerHeight)
+
+	for row := 0; row < innerHeight && items.offset+row < len(items.items); row++ {
+		ind := items.offset + row
+
+		itemStyle := style(0)
+		if ind == items.selected && focused {
+			itemStyle = styleReverse
+		}
+
+		marker := "  "
+		if ind == items.selected {
+			marker = "> "
+		}
+
+		screen.fill(x+1, y+1+row, width-2, itemStyle)
+		screen.text(x+1, y+1+row, width-2, marker+items.items[ind], itemStyle)
+	}
+}
@@ -19,6 +19,7 @@ const (
 	envInstallPrefix     = "POKECLI_INSTALL_PREFIX"
 	envTestVerbose       = "POKECLI_TEST_VERBOSE"
 	envTestCover         = "POKECLI_TEST_COVER"
+	envTestRace          = "POKECLI_TEST_RACE"
 	envBuildRebuildAll   = "POKECLI_BUILD_REBUILD_ALL"
 	envBuildVerbose      = "POKECLI_BUILD_VERBOSE"
 )
@@ -31,6 +32,7 @@ var (
 // Test run the go tests.
 // To enable verbose mode set POKECLI_TEST_VERBOSE=1.
 // To enable coverage mode set POKECLI_TEST_COVER=1.
+// To enable the race detector set POKECLI_TEST_RACE=1.
 func Test() error {
 	goTest := sh.RunCmd("go", "test")
 
@@ -44,6 +46,10 @@ func Test() error {
 		args = append(args, "-cover")
 	}
 
+	if os.Getenv(envTestRace) == "1" {
+		args = append(args, "-race")
+	}
+
 	return goTest(args...)
 }
 